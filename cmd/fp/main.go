@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `usage: fp <command> [arguments]
+
+commands:
+    run [--timeout 10s] [--path dir:dir] file.lisp [args...]  run a script, args are available as list argv
+                                                              limits: --max-depth --max-steps --max-list --max-string --max-int-bits --max-alloc
+    check file.lisp [file.lisp...]                            parse scripts without running them
+    repl                                                      start an interactive repl
+    man                                                       print manual of builtin modules and extensions
+`
+
+// stdout, stderr : outputs of the commands, replaced by tests
+var (
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+func write(format string, args ...any) {
+	_, _ = fmt.Fprintf(stderr, format, args...)
+	if f, ok := stderr.(*os.File); ok {
+		_ = f.Sync() // flush
+	}
+}
+
+func writeln(format string, args ...any) {
+	write(format+"\n", args...)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		write(usage)
+		os.Exit(2)
+	}
+	command, args := os.Args[1], os.Args[2:]
+	switch command {
+	case "run":
+		os.Exit(runCommand(args))
+	case "check":
+		os.Exit(checkCommand(args))
+	case "repl":
+		replCommand()
+	case "man":
+		manCommand()
+	case "help", "-h", "--help":
+		write(usage)
+	default:
+		writeln("unknown command %s", command)
+		write(usage)
+		os.Exit(2)
+	}
+}
@@ -2,31 +2,20 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"fp/pkg/fp"
-	"os"
 	"sort"
 )
 
-func write(format string, args ...any) {
-	_, _ = fmt.Fprintf(os.Stderr, format, args...)
-	_ = os.Stderr.Sync() // flush
-}
-
-func writeln(format string, args ...any) {
-	write(format+"\n", args...)
-}
-
-func main() {
+func manCommand() {
 	r := fp.NewStdRuntime()
 	writeln("welcome to fp repl! type function or module name for help")
 	var funcNameList []string
-	for k := range r.Stack[0] {
+	for k := range r.Stack[0].Frame {
 		funcNameList = append(funcNameList, string(k))
 	}
 	sort.Strings(funcNameList)
 	for _, name := range funcNameList {
-		o, err := r.Step(context.Background(), fp.NameExpr(name))
+		o, err := r.Step(context.Background(), fp.NameExpr{Name: name})
 		if err != nil {
 			panic(err)
 		}
@@ -14,10 +14,10 @@ import (
 	"syscall"
 )
 
-func main() {
+func replCommand() {
 	replMtx := &sync.Mutex{}
 	repl, welcome := repl.NewFP(fp.NewStdRuntime())
-	_, _ = fmt.Fprintf(os.Stderr, welcome)
+	_, _ = fmt.Fprint(os.Stderr, welcome)
 
 	rl, err := readline.NewEx(&readline.Config{
 		Prompt:          ">>> ",                 // Default prompt
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fp/pkg/fp"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// parseFile : tokenize and parse a whole script
+func parseFile(path string) ([]fp.Expr, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return fp.ParseAll(fp.TokenizeFile(path, string(b)))
+}
+
+func writeError(err error) {
+	var evalErr *fp.EvalError
+	if errors.As(err, &evalErr) {
+		writeln("%s", evalErr.Traceback())
+	} else {
+		writeln("%s", err.Error())
+	}
+}
+
+// interruptGracePeriod : time given to a script to stop after SIGINT or SIGTERM before the process exits
+const interruptGracePeriod = 5 * time.Second
+
+func runCommand(args []string) int {
+	flags := flag.NewFlagSet("run", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	timeout := flags.Duration("timeout", 0, "stop the script after this duration (0 means no timeout)")
+	searchPath := flags.String("path", os.Getenv("FP_PATH"), "colon-separated directories to look for imported files")
+	limits := fp.DefaultLimits
+	flags.IntVar(&limits.MaxStackDepth, "max-depth", limits.MaxStackDepth, "maximum number of nested calls (0 means no limit)")
+	flags.Int64Var(&limits.MaxSteps, "max-steps", limits.MaxSteps, "maximum number of evaluation steps of each top-level expression (0 means no limit)")
+	flags.IntVar(&limits.MaxListLength, "max-list", limits.MaxListLength, "maximum length of lists and dicts (0 means no limit)")
+	flags.IntVar(&limits.MaxStringSize, "max-string", limits.MaxStringSize, "maximum size of strings in bytes (0 means no limit)")
+	flags.IntVar(&limits.MaxIntBits, "max-int-bits", limits.MaxIntBits, "maximum number of bits of integers (0 means no limit)")
+	flags.Int64Var(&limits.MaxAllocations, "max-alloc", limits.MaxAllocations, "maximum number of objects created by each top-level expression (0 means no limit)")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() < 1 {
+		writeln("usage: fp run [--timeout 10s] [--path dir:dir] [--max-steps n ...] file.lisp [args...]")
+		return 2
+	}
+	path := flags.Arg(0)
+	exprList, err := parseFile(path)
+	if err != nil {
+		writeError(err)
+		return 1
+	}
+
+	r := fp.NewStdRuntime()
+	r.Stdout, r.Stderr = stdout, stderr
+	r.Limits = limits
+	if *searchPath != "" {
+		r.SearchPath = filepath.SplitList(*searchPath)
+	}
+	var argv fp.List
+	for _, arg := range flags.Args() {
+		argv = append(argv, fp.String(arg))
+	}
+	r.SetGlobal("argv", argv)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if *timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeout(ctx, *timeout)
+		defer cancelTimeout()
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signalCh)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// receive SIGINT, SIGTERM when running code -> stop code
+		select {
+		case <-signalCh:
+		case <-done:
+			return
+		}
+		cancel()
+		// a native operation may not check the context, exit on the second signal or after a grace period
+		select {
+		case <-signalCh:
+		case <-time.After(interruptGracePeriod):
+		case <-done:
+			return
+		}
+		writeln("interrupted")
+		os.Exit(130)
+	}()
+
+	for _, expr := range exprList {
+		if _, err := r.Step(ctx, expr); err != nil {
+			writeError(err)
+			return 1
+		}
+	}
+	return 0
+}
+
+func checkCommand(args []string) int {
+	if len(args) < 1 {
+		writeln("usage: fp check file.lisp [file.lisp...]")
+		return 2
+	}
+	code := 0
+	for _, path := range args {
+		if _, err := parseFile(path); err != nil {
+			writeError(err)
+			code = 1
+		}
+	}
+	return code
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// capture : replace stdout and stderr by buffers until the test ends
+func capture(t *testing.T) (*bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	oldOut, oldErr := stdout, stderr
+	stdout, stderr = out, errOut
+	t.Cleanup(func() {
+		stdout, stderr = oldOut, oldErr
+	})
+	return out, errOut
+}
+
+// script : write code to a file in a temporary directory, its path
+func script(t *testing.T, name string, code string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestRunExitCodes : 0 on success, 1 on parse or evaluation errors, 2 on usage errors
+func TestRunExitCodes(t *testing.T) {
+	tests := []struct {
+		name string
+		args func(t *testing.T) []string
+		want int
+	}{
+		{"ok", func(t *testing.T) []string { return []string{script(t, "ok.lisp", `(let x 1) (add x 1)`)} }, 0},
+		{"runtime error", func(t *testing.T) []string { return []string{script(t, "div.lisp", `(div 1 0)`)} }, 1},
+		{"parse error", func(t *testing.T) []string { return []string{script(t, "bad.lisp", `(add 1`)} }, 1},
+		{"missing file", func(t *testing.T) []string { return []string{filepath.Join(t.TempDir(), "missing.lisp")} }, 1},
+		{"timeout", func(t *testing.T) []string {
+			return []string{"--timeout", "100ms", "--max-steps", "0", script(t, "loop.lisp", `(let loop (lambda n (loop n))) (loop 1)`)}
+		}, 1},
+		{"step limit", func(t *testing.T) []string {
+			return []string{"--max-steps", "1000", script(t, "loop.lisp", `(let loop (lambda n (loop n))) (loop 1)`)}
+		}, 1},
+		{"int bits limit", func(t *testing.T) []string {
+			return []string{"--max-int-bits", "1000", script(t, "pow.lisp", `(pow 3 4000000000)`)}
+		}, 1},
+		{"no file", func(t *testing.T) []string { return nil }, 2},
+		{"unknown flag", func(t *testing.T) []string { return []string{"--nope", "x.lisp"} }, 2},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			capture(t)
+			if got := runCommand(c.args(t)); got != c.want {
+				t.Errorf("got exit code %d want %d", got, c.want)
+			}
+		})
+	}
+}
+
+// TestRunArgv : argv is the script path followed by the arguments after it
+func TestRunArgv(t *testing.T) {
+	out, _ := capture(t)
+	path := filepath.Join(t.TempDir(), "argv.lisp")
+	code := fmt.Sprintf(`(println (len argv) (eq (peek argv 1) %s) (peek argv 2) (peek argv 3))`, strconv.Quote(path))
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := runCommand([]string{path, "a", "--b=100%"}); code != 0 {
+		t.Fatalf("got exit code %d", code)
+	}
+	if got, want := out.String(), "3 true a --b=100%\n"; got != want {
+		t.Errorf("got %q want %q", got, want)
+	}
+}
+
+// TestRunErrorOutput : errors are printed with their traceback, % in source and messages is kept as is
+func TestRunErrorOutput(t *testing.T) {
+	_, errOut := capture(t)
+	path := script(t, "err.lisp", `(let f (lambda s (get (dict) s)))
+(f "100%s done")`)
+	if code := runCommand([]string{path}); code != 1 {
+		t.Fatalf("got exit code %d want 1", code)
+	}
+	for _, want := range []string{`(f "100%s done")`, `key 100%s done not found`, path + ":2:1"} {
+		if !strings.Contains(errOut.String(), want) {
+			t.Errorf("output %q does not contain %q", errOut.String(), want)
+		}
+	}
+}
+
+// TestCheck : parse errors are printed with their position, valid files print nothing
+func TestCheck(t *testing.T) {
+	_, errOut := capture(t)
+	good := script(t, "good.lisp", `(add 1 2)`)
+	bad := script(t, "bad.lisp", "(add 1\n  (mul 2")
+	if code := checkCommand([]string{good}); code != 0 || errOut.Len() != 0 {
+		t.Errorf("good file: got exit code %d and output %q", code, errOut.String())
+	}
+	if code := checkCommand([]string{good, bad}); code != 1 {
+		t.Errorf("bad file: got exit code %d want 1", code)
+	}
+	if want := bad + `:2:3: parse error: expected ")" to close "(", found end of input`; !strings.Contains(errOut.String(), want) {
+		t.Errorf("output %q does not contain %q", errOut.String(), want)
+	}
+	if code := checkCommand(nil); code != 2 {
+		t.Errorf("no file: got exit code %d want 2", code)
+	}
+}
+
+// TestRunExample : the example script runs with the default limits
+func TestRunExample(t *testing.T) {
+	capture(t)
+	if code := runCommand([]string{filepath.Join("..", "..", "example.lisp")}); code != 0 {
+		t.Errorf("got exit code %d want 0", code)
+	}
+}
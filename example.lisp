@@ -96,22 +96,10 @@
 (let x (list 1 2 3))
 (map x (lambda y (add 1 y)))
 
-// if else statement
-(
-    let gt (lambda x y (sign (sub x y)))    // return 1 if x > y
-)
-
-(
-    let if (lambda x f g (                  // return (f) if x is 1 and (g) otherwise
-        case x
-        1 (f)
-        _ (g)
-    ))
-)
-
+// if else statement - only the chosen branch is evaluated
 (let x 3)
 
-(if (gt x 2) (lambda 5) (lambda 6))
+(if (gt x 2) 5 6)
 
 //test TCO
 
@@ -119,12 +107,22 @@
     let count (lambda n (
         case n
         0 0
-        _ (add 1 (count (sub n 1)))
+        _ (add 1 (count (sub n 1)))                                         // not a tail call, bounded by the stack depth limit
     ))
 )
 
 (count 2000)
 
+(
+    let count-tail (lambda n acc (
+        case n
+        0 acc
+        _ (count-tail (sub n 1) (add acc 1))                                // tail call, runs in constant stack
+    ))
+)
+
+(count-tail 100000 0)
+
 // writing my own map instead of using builtin map
 (let map1 (lambda l f (tail
     (let length (len l))
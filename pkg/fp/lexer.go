@@ -2,69 +2,138 @@ package fp
 
 import (
 	"fmt"
-	"strings"
 	"unicode"
 )
 
-type Token = string
+// Pos : 1-indexing line and column of a character in a source
+type Pos struct {
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// Span : location of a token or an expression in a source, End is inclusive
+type Span struct {
+	File  string `json:"file,omitempty"`
+	Start Pos    `json:"start,omitempty"`
+	End   Pos    `json:"end,omitempty"`
+}
+
+// IsZero : span is unknown, e.g. expression is not from source
+func (s Span) IsZero() bool {
+	return s.Start.Line == 0
+}
 
-func removeComments(str string) string {
-	lines := strings.Split(str, "\n")
-	var newLines []string
-	for _, line := range lines {
-		newLines = append(newLines, strings.Split(line, "//")[0])
+func (s Span) String() string {
+	if s.IsZero() {
+		return "<unknown>"
 	}
-	return strings.Join(newLines, "\n")
+	file := s.File
+	if file == "" {
+		file = "<input>"
+	}
+	return fmt.Sprintf("%s:%d:%d", file, s.Start.Line, s.Start.Column)
+}
+
+// Join : span from start of s to end of o
+func (s Span) Join(o Span) Span {
+	return Span{
+		File:  s.File,
+		Start: s.Start,
+		End:   o.End,
+	}
+}
+
+type Token struct {
+	Value string `json:"value,omitempty"`
+	Span  Span   `json:"span,omitempty"`
+}
+
+func (t Token) String() string {
+	return t.Value
 }
 
+// Tokenize : tokenize a source without file name
 func Tokenize(str string) []Token {
-	str = removeComments(str)
+	return TokenizeFile("", str)
+}
 
+// TokenizeFile : tokenize a source, every token carries its span in file
+func TokenizeFile(file string, str string) []Token {
 	const (
 		STATE_OUTSTRING = iota
 		STATE_INSTRING
 		STATE_INSTRING_ESCAPE
+		STATE_COMMENT
 	)
 
 	var tokens []Token
 	state := STATE_OUTSTRING
 	buffer := ""
+	var start, last Pos
 	flushBuffer := func() {
 		if len(buffer) > 0 {
-			tokens = append(tokens, buffer)
+			tokens = append(tokens, Token{
+				Value: buffer,
+				Span: Span{
+					File:  file,
+					Start: start,
+					End:   last,
+				},
+			})
 		}
 		buffer = ""
 	}
-	for _, ch := range str {
+	pushChar := func(ch rune, pos Pos) {
+		if len(buffer) == 0 {
+			start = pos
+		}
+		buffer += string(ch)
+		last = pos
+	}
+	chars := []rune(str)
+	pos := Pos{Line: 1, Column: 0}
+	for i, ch := range chars {
+		if i > 0 && chars[i-1] == '\n' {
+			pos = Pos{Line: pos.Line + 1, Column: 1}
+		} else {
+			pos.Column++
+		}
 		switch state {
 		case STATE_OUTSTRING:
-			if unicode.IsSpace(ch) {
+			if ch == '/' && i+1 < len(chars) && chars[i+1] == '/' {
+				flushBuffer()
+				state = STATE_COMMENT
+			} else if unicode.IsSpace(ch) {
 				flushBuffer()
 			} else if ch == '(' || ch == ')' || ch == '*' {
 				flushBuffer()
-				buffer += string(ch)
+				pushChar(ch, pos)
 				flushBuffer()
 			} else if ch == '"' {
 				flushBuffer()
-				buffer += string(ch)
+				pushChar(ch, pos)
 				state = STATE_INSTRING
 			} else {
-				buffer += string(ch)
+				pushChar(ch, pos)
 			}
 		case STATE_INSTRING:
 			if ch == '\\' {
-				buffer += string(ch)
+				pushChar(ch, pos)
 				state = STATE_INSTRING_ESCAPE
 			} else if ch == '"' {
-				buffer += string(ch)
+				pushChar(ch, pos)
 				flushBuffer()
 				state = STATE_OUTSTRING
 			} else {
-				buffer += string(ch)
+				pushChar(ch, pos)
 			}
 		case STATE_INSTRING_ESCAPE:
-			buffer += string(ch)
+			pushChar(ch, pos)
 			state = STATE_INSTRING
+		case STATE_COMMENT:
+			if ch == '\n' {
+				state = STATE_OUTSTRING
+			}
 		default:
 			panic(fmt.Sprintf("invalid state: %d", state))
 		}
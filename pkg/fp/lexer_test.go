@@ -0,0 +1,88 @@
+package fp
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func pos(line int, column int) Pos {
+	return Pos{Line: line, Column: column}
+}
+
+// TestTokenizeSpans : every token carries the file, line and column of its first and last character
+func TestTokenizeSpans(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want []Token
+	}{
+		{"call", `(add 1 22)`, []Token{
+			{"(", Span{"f", pos(1, 1), pos(1, 1)}},
+			{"add", Span{"f", pos(1, 2), pos(1, 4)}},
+			{"1", Span{"f", pos(1, 6), pos(1, 6)}},
+			{"22", Span{"f", pos(1, 8), pos(1, 9)}},
+			{")", Span{"f", pos(1, 10), pos(1, 10)}},
+		}},
+		{"lines", "x\n  y", []Token{
+			{"x", Span{"f", pos(1, 1), pos(1, 1)}},
+			{"y", Span{"f", pos(2, 3), pos(2, 3)}},
+		}},
+		{"string", `"a b\"c"`, []Token{
+			{`"a b\"c"`, Span{"f", pos(1, 1), pos(1, 8)}},
+		}},
+		{"comment", "// (x)\ny // z", []Token{
+			{"y", Span{"f", pos(2, 1), pos(2, 1)}},
+		}},
+		{"unicode", `"héllo" x`, []Token{
+			{`"héllo"`, Span{"f", pos(1, 1), pos(1, 7)}},
+			{"x", Span{"f", pos(1, 9), pos(1, 9)}},
+		}},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			got := TokenizeFile("f", c.code)
+			if len(got) != len(c.want) {
+				t.Fatalf("got %v want %v", got, c.want)
+			}
+			for i := range got {
+				if got[i] != c.want[i] {
+					t.Errorf("token %d: got %#v want %#v", i, got[i], c.want[i])
+				}
+			}
+		})
+	}
+}
+
+// TestExprSpans : a call spans from its "(" to its ")", its name and arguments keep their own spans
+func TestExprSpans(t *testing.T) {
+	exprs, err := ParseAll(TokenizeFile("f", "(add 1\n  (mul 2 3))"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := exprs[0].(LambdaExpr)
+	if want := (Span{"f", pos(1, 1), pos(2, 12)}); call.Span != want {
+		t.Errorf("call: got %#v want %#v", call.Span, want)
+	}
+	if want := (Span{"f", pos(1, 2), pos(1, 4)}); call.Name.Span != want {
+		t.Errorf("name: got %#v want %#v", call.Name.Span, want)
+	}
+	if want := (Span{"f", pos(2, 3), pos(2, 11)}); exprSpan(call.Args[1]) != want {
+		t.Errorf("argument: got %#v want %#v", exprSpan(call.Args[1]), want)
+	}
+}
+
+// TestErrorPosition : runtime errors start with the position of the failing expression
+func TestErrorPosition(t *testing.T) {
+	exprs, err := ParseAll(TokenizeFile("f.lisp", "(let x 1)\n(add x\n  (div 1 0))"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewStdRuntime()
+	for _, expr := range exprs {
+		_, err = r.Step(context.Background(), expr)
+	}
+	if err == nil || !strings.HasPrefix(err.Error(), "f.lisp:3:3: ") {
+		t.Errorf("got %v want an error at f.lisp:3:3", err)
+	}
+}
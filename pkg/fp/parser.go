@@ -1,20 +1,30 @@
 package fp
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
 	"slices"
+	"strconv"
+	"strings"
 )
 
-// Expr : union of NameExpr, LambdaExpr
+// Expr : union of NameExpr, LambdaExpr, ValueExpr
 type Expr interface {
 	String() string
 	MustTypeExpr() // for type-safety every Expr must implement this
 }
 
-type NameExpr string
+// NameExpr : literal or variable name
+type NameExpr struct {
+	Name string `json:"name,omitempty"`
+	Span Span   `json:"span,omitempty"`
+}
 
 func (e NameExpr) String() string {
-	return string(e)
+	return e.Name
 }
 
 func (e NameExpr) MustTypeExpr() {
@@ -22,8 +32,9 @@ func (e NameExpr) MustTypeExpr() {
 
 // LambdaExpr : S-expression
 type LambdaExpr struct {
-	Name NameExpr
-	Args []Expr
+	Name NameExpr `json:"name,omitempty"`
+	Args []Expr   `json:"args,omitempty"`
+	Span Span     `json:"span,omitempty"`
 }
 
 func (e LambdaExpr) String() string {
@@ -41,26 +52,119 @@ func (e LambdaExpr) MustTypeExpr() {
 
 }
 
-func pop(tokenList []Token) ([]Token, Token, error) {
-	if len(tokenList) == 0 {
-		return nil, "", errors.New("empty token list")
+// ValueExpr : value already evaluated, in expressions built from data by quasiquote and eval, never parsed
+type ValueExpr struct {
+	Value Object `json:"value,omitempty"`
+	Span  Span   `json:"span,omitempty"`
+}
+
+func (e ValueExpr) String() string {
+	if s, ok := e.Value.(String); ok {
+		return strconv.Quote(string(s))
 	}
-	return tokenList[1:], tokenList[0], nil
+	return fmt.Sprintf("%v", e.Value)
+}
+
+func (e ValueExpr) MustTypeExpr() {
+}
+
+// ParseError : malformed input, Span is where the parser expected something else
+type ParseError struct {
+	Expected string `json:"expected,omitempty"`
+	Found    string `json:"found,omitempty"`
+	Span     Span   `json:"span,omitempty"`
+	eof      bool   // input ended before the expression was complete
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%s: parse error: expected %s, found %s", e.Span.String(), e.Expected, e.Found)
+}
+
+// Incomplete : input is a valid prefix, more tokens may complete it
+func (e *ParseError) Incomplete() bool {
+	return e.eof
+}
+
+const foundEndOfInput = "end of input"
+
+func quoteToken(tok Token) string {
+	return fmt.Sprintf("%q", tok.Value)
+}
+
+// isUnterminatedString : token starts a string literal but the closing quote is missing
+func isUnterminatedString(tok Token) bool {
+	if !strings.HasPrefix(tok.Value, `"`) {
+		return false
+	}
+	chars := []rune(tok.Value)
+	for i := 1; i < len(chars); i++ {
+		switch chars[i] {
+		case '\\':
+			i++
+		case '"':
+			return i != len(chars)-1
+		}
+	}
+	return true
+}
+
+// floatLiteral : decimal number with a fraction or an exponent, e.g. 3.14 .5 1e-3 -2.5E+10
+var floatLiteral = regexp.MustCompile(`^[+-]?(\d+\.\d*|\.\d+|\d+)([eE][+-]?\d+)?$`)
+
+// parseLiteral : value of a literal token, ok is false if the token is a name
+//
+// err is set for a string literal with an invalid escape
+func parseLiteral(lit string) (v Object, ok bool, err error) {
+	if len(lit) == 0 {
+		return nil, false, nil
+	}
+	switch lit {
+	case "_":
+		return Wildcard{}, true, nil
+	case "*":
+		return Unwrap{}, true, nil
+	case "true", "false":
+		return Bool(lit == "true"), true, nil
+	}
+	if lit[0] == '"' {
+		str := ""
+		if err := json.Unmarshal([]byte(lit), &str); err != nil {
+			return nil, true, err
+		}
+		return String(str), true, nil
+	}
+	if lit[0] != '-' && lit[0] != '+' && lit[0] != '.' && (lit[0] < '0' || lit[0] > '9') {
+		return nil, false, nil // fast path for names
+	}
+	i, err := strconv.Atoi(lit)
+	if err == nil {
+		return Int(i), true, nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		if v, ok := new(big.Int).SetString(lit, 10); ok {
+			return normalizeBig(v), true, nil
+		}
+	}
+	if floatLiteral.MatchString(lit) {
+		f, err := strconv.ParseFloat(lit, 64)
+		return Float(f), err == nil, nil
+	}
+	return nil, false, nil
 }
 
 // ParseAll : parse a token list
-func ParseAll(tokenList []Token) ([]Expr, []Token) {
+func ParseAll(tokenList []Token) ([]Expr, error) {
 	var expr Expr
 	var exprList []Expr
 	var err error
 	for len(tokenList) > 0 {
 		expr, tokenList, err = parseSingle(tokenList)
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		exprList = append(exprList, expr)
 	}
-	return exprList, tokenList
+	return exprList, nil
 }
 
 type Parser struct {
@@ -71,69 +175,131 @@ func (p *Parser) Clear() {
 	p.Buffer = []Token{}
 }
 
-func (p *Parser) Input(tok Token) Expr {
+// Input : feed a token, return an expression once one is complete
+//
+// the buffer is cleared if the tokens can never form a valid expression
+func (p *Parser) Input(tok Token) (Expr, error) {
 	p.Buffer = append(p.Buffer, tok)
 	// try parse single // TODO : do this for simplicity
 	buffer := slices.Clone(p.Buffer)
 	expr, buffer, err := parseSingle(buffer)
 	if err != nil {
-		// parse fail - don't do anything
-		return nil
-	} else {
-		// parse ok - update buffer
-		p.Buffer = buffer
-		return expr
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) && parseErr.Incomplete() {
+			// parse incomplete - wait for more tokens
+			return nil, nil
+		}
+		p.Clear()
+		return nil, err
 	}
+	// parse ok - update buffer
+	p.Buffer = buffer
+	return expr, nil
 }
 
 func parseSingle(tokenList []Token) (Expr, []Token, error) {
-	var parse func(tokenList []Token) (Expr, []Token, bool, error)
-	parse = func(tokenList []Token) (Expr, []Token, bool, error) {
+	if len(tokenList) == 0 {
+		return nil, nil, &ParseError{
+			Expected: "expression",
+			Found:    foundEndOfInput,
+			eof:      true,
+		}
+	}
+	head, tokenList := tokenList[0], tokenList[1:]
+	switch head.Value {
+	case ")":
+		return nil, nil, &ParseError{
+			Expected: "expression",
+			Found:    "unbalanced \")\"",
+			Span:     head.Span,
+		}
+	case "(": // start with Open
 		if len(tokenList) == 0 {
-			return nil, nil, false, errors.New("empty token list")
+			return nil, nil, &ParseError{
+				Expected: "function name after \"(\"",
+				Found:    foundEndOfInput,
+				Span:     head.Span,
+				eof:      true,
+			}
 		}
-		tokenList, head, err := pop(tokenList) // pop ( or [ or name
-		if err != nil {
-			return nil, nil, false, err
+		funcName := tokenList[0]
+		switch {
+		case funcName.Value == ")":
+			return nil, nil, &ParseError{
+				Expected: "function name",
+				Found:    "empty call ()",
+				Span:     head.Span,
+			}
+		case funcName.Value == "(":
+			return nil, nil, &ParseError{
+				Expected: "function name",
+				Found:    quoteToken(funcName),
+				Span:     funcName.Span,
+			}
+		case isUnterminatedString(funcName):
+			return nil, nil, &ParseError{
+				Expected: "closing \" of string",
+				Found:    foundEndOfInput,
+				Span:     funcName.Span,
+			}
 		}
-		switch head {
-		case "(": // start with Open
-			tokenList, funcName, err := pop(tokenList)
-			if err != nil {
-				return nil, nil, false, err
+		tokenList = tokenList[1:]
+		var exprList []Expr
+		for {
+			if len(tokenList) == 0 {
+				return nil, nil, &ParseError{
+					Expected: "\")\" to close \"(\"",
+					Found:    foundEndOfInput,
+					Span:     head.Span,
+					eof:      true,
+				}
 			}
-			if funcName == ")" { // empty
-				return parse(tokenList)
+			if tokenList[0].Value == ")" {
+				// end with Close
+				break
 			}
 			var expr Expr
-			var exprList []Expr
-			var endWithClose bool
-			for {
-				expr, tokenList, endWithClose, err = parse(tokenList)
-				if err != nil {
-					return nil, nil, false, err
-				}
-				if endWithClose {
-					// end with Close
-					break
-				}
-				exprList = append(exprList, expr)
+			var err error
+			expr, tokenList, err = parseSingle(tokenList)
+			if err != nil {
+				return nil, nil, err
 			}
-			return LambdaExpr{
-				Name: NameExpr(funcName),
-				Args: exprList,
-			}, tokenList, false, nil
-		default:
-			return NameExpr(head), tokenList, head == ")", nil
+			exprList = append(exprList, expr)
 		}
+		closeTok := tokenList[0]
+		return LambdaExpr{
+			Name: NameExpr{
+				Name: funcName.Value,
+				Span: funcName.Span,
+			},
+			Args: exprList,
+			Span: head.Span.Join(closeTok.Span),
+		}, tokenList[1:], nil
+	default:
+		if isUnterminatedString(head) {
+			return nil, nil, &ParseError{
+				Expected: "closing \" of string",
+				Found:    foundEndOfInput,
+				Span:     head.Span,
+			}
+		}
+		v, ok, err := parseLiteral(head.Value)
+		if err != nil {
+			return nil, nil, &ParseError{
+				Expected: "valid string literal",
+				Found:    quoteToken(head),
+				Span:     head.Span,
+			}
+		}
+		if ok {
+			return ValueExpr{
+				Value: v,
+				Span:  head.Span,
+			}, tokenList, nil
+		}
+		return NameExpr{
+			Name: head.Value,
+			Span: head.Span,
+		}, tokenList, nil
 	}
-
-	expr, tokenList, endWithClose, err := parse(tokenList)
-	if err != nil {
-		return nil, nil, err
-	}
-	if endWithClose {
-		return nil, nil, errors.New("parse error")
-	}
-	return expr, tokenList, nil
 }
@@ -0,0 +1,112 @@
+package fp
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestParseError : malformed input returns a ParseError at the offending token instead of panicking
+func TestParseError(t *testing.T) {
+	tests := []struct {
+		code       string
+		expected   string
+		found      string
+		at         Pos
+		incomplete bool
+	}{
+		{`)`, "expression", `unbalanced ")"`, pos(1, 1), false},
+		{`()`, "function name", "empty call ()", pos(1, 1), false},
+		{`((f) 1)`, "function name", `"("`, pos(1, 2), false},
+		{`(`, `function name after "("`, foundEndOfInput, pos(1, 1), true},
+		{"(add 1\n  (mul 2", `")" to close "("`, foundEndOfInput, pos(2, 3), true},
+		{`(print "abc`, `closing " of string`, foundEndOfInput, pos(1, 8), false},
+		{`"abc`, `closing " of string`, foundEndOfInput, pos(1, 1), false},
+	}
+	for _, c := range tests {
+		_, err := ParseAll(Tokenize(c.code))
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: got %v want a ParseError", c.code, err)
+			continue
+		}
+		if parseErr.Expected != c.expected || parseErr.Found != c.found {
+			t.Errorf("%s: got expected %s, found %s want expected %s, found %s", c.code, parseErr.Expected, parseErr.Found, c.expected, c.found)
+		}
+		if parseErr.Span.Start != c.at {
+			t.Errorf("%s: got position %v want %v", c.code, parseErr.Span.Start, c.at)
+		}
+		if parseErr.Incomplete() != c.incomplete {
+			t.Errorf("%s: got incomplete %v want %v", c.code, parseErr.Incomplete(), c.incomplete)
+		}
+	}
+}
+
+// TestParserInput : the parser waits for incomplete expressions and clears its buffer on errors
+func TestParserInput(t *testing.T) {
+	p := &Parser{}
+	var exprs []Expr
+	for _, tok := range Tokenize("(add 1\n 2) x") {
+		expr, err := p.Input(tok)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expr != nil {
+			exprs = append(exprs, expr)
+		}
+	}
+	if len(exprs) != 2 || exprs[0].String() != "(add 1 2)" || exprs[1].String() != "x" {
+		t.Errorf("got %v want [(add 1 2) x]", exprs)
+	}
+	if _, err := p.Input(Token{Value: ")"}); err == nil {
+		t.Errorf("expected an error on unbalanced )")
+	}
+	if len(p.Buffer) != 0 {
+		t.Errorf("got buffer %v want empty after an error", p.Buffer)
+	}
+}
+
+// TestParseLiterals : literals are parsed once into ValueExpr, other tokens are names
+func TestParseLiterals(t *testing.T) {
+	tests := []struct {
+		token string
+		want  Object // nil for a name
+	}{
+		{`1`, Int(1)},
+		{`-42`, Int(-42)},
+		{`99999999999999999999`, bigInt("99999999999999999999")},
+		{`1.5`, Float(1.5)},
+		{`.5`, Float(0.5)},
+		{`-2.5E+10`, Float(-2.5e10)},
+		{`"a\nb"`, String("a\nb")},
+		{`true`, Bool(true)},
+		{`_`, Wildcard{}},
+		{`*`, Unwrap{}},
+		{`x`, nil},
+		{`-`, nil},
+		{`->`, nil},
+		{`1+`, nil},
+		{`inf`, nil},
+	}
+	for _, c := range tests {
+		exprs, err := ParseAll(Tokenize(c.token))
+		if err != nil {
+			t.Errorf("%s: %v", c.token, err)
+			continue
+		}
+		switch e := exprs[0].(type) {
+		case ValueExpr:
+			if c.want == nil || !Equal(e.Value, c.want) {
+				t.Errorf("%s: got literal %#v want %#v", c.token, e.Value, c.want)
+			}
+		case NameExpr:
+			if c.want != nil {
+				t.Errorf("%s: got name want literal %#v", c.token, c.want)
+			}
+		default:
+			t.Errorf("%s: got %#v", c.token, e)
+		}
+	}
+	if _, err := ParseAll(Tokenize(`"a\qb"`)); err == nil {
+		t.Errorf("invalid escape: expected a ParseError")
+	}
+}
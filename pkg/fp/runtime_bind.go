@@ -0,0 +1,288 @@
+package fp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+	bigIntType  = reflect.TypeOf((*big.Int)(nil))
+)
+
+// Bind : load a Go function as an extension, arguments and results are converted by reflection
+//
+// parameters can be numbers, strings, bools, slices, arrays, maps, structs (from Dict by field name or json tag),
+// pointers, *big.Int, Object or any (converted by ToGo), a first context.Context parameter receives the context
+// of the evaluation. results are converted by FromGo, several results make a List and a last error result
+// is returned as the error of the call
+func (r *Runtime) Bind(name string, fn any) error {
+	f := reflect.ValueOf(fn)
+	if f.Kind() != reflect.Func || f.IsNil() {
+		return fmt.Errorf("bind %s: %T is not a function", name, fn)
+	}
+	t := f.Type()
+	withContext := t.NumIn() > 0 && t.In(0) == contextType
+	var params []reflect.Type
+	for i := 0; i < t.NumIn(); i++ {
+		if i == 0 && withContext {
+			continue
+		}
+		params = append(params, t.In(i))
+	}
+	withError := t.NumOut() > 0 && t.Out(t.NumOut()-1) == errorType
+	r.LoadExtension(Extension{
+		Name: String(name),
+		Exec: func(ctx context.Context, values ...Object) (Object, error) {
+			in, err := bindArgs(String(name), params, t.IsVariadic(), values)
+			if err != nil {
+				return nil, err
+			}
+			if withContext {
+				in = append([]reflect.Value{reflect.ValueOf(ctx)}, in...)
+			}
+			return bindCall(String(name), f, in, withError)
+		},
+		Man: bindMan(name, params, t),
+	})
+	return nil
+}
+
+// bindArgs : convert arguments to the parameter types of a function
+func bindArgs(name String, params []reflect.Type, variadic bool, values []Object) ([]reflect.Value, error) {
+	fixed := len(params)
+	if variadic {
+		fixed--
+		if len(values) < fixed {
+			return nil, newError(ErrorArity, "%s requires at least %d arguments but got %d", name, fixed, len(values))
+		}
+	} else if len(values) != fixed {
+		return nil, newError(ErrorArity, "%s requires %d arguments but got %d", name, fixed, len(values))
+	}
+	var in []reflect.Value
+	for i, v := range values {
+		t := params[min(i, len(params)-1)]
+		if i >= fixed {
+			t = t.Elem() // element of the variadic slice
+		}
+		arg, err := toGoValue(v, t)
+		if err != nil {
+			return nil, newError(ErrorTypeMismatch, "%s argument %d: %v", name, i+1, err)
+		}
+		in = append(in, arg)
+	}
+	return in, nil
+}
+
+// bindCall : call a function and convert its results, a panic is returned as an error
+func bindCall(name String, f reflect.Value, in []reflect.Value, withError bool) (v Object, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			v, err = nil, newError(ErrorRuntime, "%s panicked: %v", name, p)
+		}
+	}()
+	out := f.Call(in)
+	if withError {
+		if e := out[len(out)-1]; !e.IsNil() {
+			return nil, e.Interface().(error)
+		}
+		out = out[:len(out)-1]
+	}
+	switch len(out) {
+	case 0:
+		return nil, nil
+	case 1:
+		return FromGo(out[0].Interface()), nil
+	default:
+		var l List
+		for _, o := range out {
+			l = append(l, FromGo(o.Interface()))
+		}
+		return l, nil
+	}
+}
+
+// toGoValue : convert an Object to a value of type t
+func toGoValue(o Object, t reflect.Type) (reflect.Value, error) {
+	mismatch := func() (reflect.Value, error) {
+		return reflect.Value{}, fmt.Errorf("requires %s but got %s", t, getType(o))
+	}
+	switch {
+	case o != nil && reflect.TypeOf(o) == t:
+		// Object types, e.g. List or Lambda
+		return reflect.ValueOf(o), nil
+	case t.Kind() == reflect.Interface && t.NumMethod() > 0:
+		// Object or another interface implemented by the Object, e.g. fmt.Stringer
+		if o == nil {
+			return reflect.Zero(t), nil
+		}
+		if !reflect.TypeOf(o).Implements(t) {
+			return mismatch()
+		}
+		v := reflect.New(t).Elem()
+		v.Set(reflect.ValueOf(o))
+		return v, nil
+	case t == bigIntType:
+		v, ok := toBig(o)
+		if !ok {
+			return mismatch()
+		}
+		return reflect.ValueOf(new(big.Int).Set(v)), nil
+	}
+	switch t.Kind() {
+	case reflect.Interface:
+		v := reflect.New(t).Elem()
+		if g := ToGo(o); g != nil {
+			v.Set(reflect.ValueOf(g))
+		}
+		return v, nil
+	case reflect.Pointer:
+		if o == nil {
+			return reflect.Zero(t), nil
+		}
+		elem, err := toGoValue(o, t.Elem())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		p := reflect.New(t.Elem())
+		p.Elem().Set(elem)
+		return p, nil
+	case reflect.Bool:
+		b, ok := o.(Bool)
+		if !ok {
+			return mismatch()
+		}
+		return reflect.ValueOf(bool(b)).Convert(t), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, ok := toBig(o)
+		if !ok || !v.IsInt64() || reflect.Zero(t).OverflowInt(v.Int64()) {
+			return mismatch()
+		}
+		return reflect.ValueOf(v.Int64()).Convert(t), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, ok := toBig(o)
+		if !ok || !v.IsUint64() || reflect.Zero(t).OverflowUint(v.Uint64()) {
+			return mismatch()
+		}
+		return reflect.ValueOf(v.Uint64()).Convert(t), nil
+	case reflect.Float32, reflect.Float64:
+		f, ok := toFloat(o)
+		if !ok || (t.Kind() == reflect.Float32 && math.Abs(float64(f)) > math.MaxFloat32 && !math.IsInf(float64(f), 0)) {
+			return mismatch()
+		}
+		return reflect.ValueOf(float64(f)).Convert(t), nil
+	case reflect.String:
+		s, ok := o.(String)
+		if !ok {
+			return mismatch()
+		}
+		return reflect.ValueOf(string(s)).Convert(t), nil
+	case reflect.Slice, reflect.Array:
+		l, ok := o.(List)
+		if !ok && o != nil {
+			return mismatch()
+		}
+		var v reflect.Value
+		if t.Kind() == reflect.Slice {
+			v = reflect.MakeSlice(t, len(l), len(l))
+		} else if len(l) != t.Len() {
+			return reflect.Value{}, fmt.Errorf("requires %s but got a list of length %d", t, len(l))
+		} else {
+			v = reflect.New(t).Elem()
+		}
+		for i, elem := range l {
+			e, err := toGoValue(elem, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.Index(i).Set(e)
+		}
+		return v, nil
+	case reflect.Map:
+		d, ok := o.(Dict)
+		if !ok {
+			return mismatch()
+		}
+		v := reflect.MakeMapWithSize(t, d.Len())
+		for _, entry := range d.Entries() {
+			k, err := toGoValue(entry.Key, t.Key())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			e, err := toGoValue(entry.Value, t.Elem())
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.SetMapIndex(k, e)
+		}
+		return v, nil
+	case reflect.Struct:
+		d, ok := o.(Dict)
+		if !ok {
+			return mismatch()
+		}
+		v := reflect.New(t).Elem()
+		for i := 0; i < t.NumField(); i++ {
+			name, ok := fieldName(t.Field(i))
+			if !ok {
+				continue
+			}
+			field, ok := d.Get(String(name))
+			if !ok {
+				continue
+			}
+			e, err := toGoValue(field, t.Field(i).Type)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("field %s %v", name, err)
+			}
+			v.Field(i).Set(e)
+		}
+		return v, nil
+	default:
+		return mismatch()
+	}
+}
+
+// fpTypeName : name of the Object type converted to a Go type
+func fpTypeName(t reflect.Type) string {
+	if t == bigIntType {
+		return "Int"
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "Bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "Int"
+	case reflect.Float32, reflect.Float64:
+		return "Float"
+	case reflect.String:
+		return "String"
+	case reflect.Slice, reflect.Array:
+		return "List"
+	case reflect.Map, reflect.Struct:
+		return "Dict"
+	case reflect.Pointer:
+		return fpTypeName(t.Elem())
+	default:
+		return "x"
+	}
+}
+
+// bindMan : manual of a bound function from its signature
+func bindMan(name string, params []reflect.Type, t reflect.Type) string {
+	args := []string{name}
+	for i, param := range params {
+		if t.IsVariadic() && i == len(params)-1 {
+			args = append(args, fpTypeName(param.Elem())+"...")
+			continue
+		}
+		args = append(args, fpTypeName(param))
+	}
+	return fmt.Sprintf("module: (%s) - go %s", strings.Join(args, " "), t)
+}
@@ -0,0 +1,84 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// bindRuntime : runtime with Go functions of every supported shape bound
+func bindRuntime(t *testing.T) *Runtime {
+	r := NewStdRuntime()
+	for name, fn := range map[string]any{
+		"go-max-plus-len": func(xs ...int) int { return slices.Max(append(xs, 0)) + len(xs) },
+		"go-norm":         func(p point) float64 { return float64(p.X*p.X + p.Y*p.Y) },
+		"go-swap":         func(a string, b string) (string, string) { return b, a },
+		"go-words": func(ctx context.Context, s string, n *int) (map[string]int, error) {
+			if n != nil && *n < 0 {
+				return nil, errors.New("negative")
+			}
+			counts := map[string]int{}
+			for _, w := range strings.Fields(s) {
+				counts[w]++
+			}
+			return counts, nil
+		},
+		"go-byte":  func(b uint8) uint8 { return b },
+		"go-big":   func(v *big.Int) *big.Int { return new(big.Int).Mul(v, v) },
+		"go-any":   func(v any) any { return v },
+		"go-panic": func() { panic("boom") },
+	} {
+		if err := r.Bind(name, fn); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return r
+}
+
+// TestBind : Go functions are called with converted arguments, results are converted back
+func TestBind(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(go-max-plus-len 1 5 2)`, Int(8)},
+		{`(go-max-plus-len)`, Int(0)},
+		{`(go-norm (dict "x" 3 "y" 4))`, Float(25)},
+		{`(go-swap "a" "b")`, List{String("b"), String("a")}},
+		{`(get (go-words "a b a" 1) "a")`, Int(2)},
+		{`(go-byte 255)`, Int(255)},
+		{`(go-big 99999999999999999999)`, bigInt("9999999999999999999800000000000000000001")},
+		{`(go-any (list 1 "a"))`, List{Int(1), String("a")}},
+	}
+	for _, c := range tests {
+		expect(t, bindRuntime(t), c.code, c.want)
+	}
+}
+
+// TestBindErrors : bad arguments, returned errors and panics are errors of the call
+func TestBindErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(go-words "a" -1)`, ErrorRuntime},
+		{`(go-max-plus-len "x")`, ErrorTypeMismatch},
+		{`(go-byte 256)`, ErrorTypeMismatch},
+		{`(go-swap "a")`, ErrorArity},
+		{`(go-panic)`, ErrorRuntime},
+	}
+	for _, c := range tests {
+		expectError(t, bindRuntime(t), c.code, c.kind)
+	}
+	if err := NewStdRuntime().Bind("bad", 1); err == nil {
+		t.Errorf("bind accepted a non-function")
+	}
+}
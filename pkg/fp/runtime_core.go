@@ -4,14 +4,40 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"os"
+	"slices"
+	"sync"
 	"time"
 )
 
+// Runtime : evaluates expressions
+//
+// concurrency contract: a Runtime is not safe for concurrent use, each goroutine evaluates with its own Runtime.
+// Fork makes a child Runtime for another goroutine. Many goroutines can Fork the same Runtime at the same time as long as
+// it is not evaluating. Frames present at fork time are shared copy-on-write, neither the parent nor the children
+// modify them in place, so a preloaded Runtime can serve many concurrent evaluations and can still be used afterward.
+// Frames must be written with let, del, LoadModule or SetGlobal, not through Stack directly, once a Runtime is forked.
+// Release a fork when it is done so that the parent writes its frames in place again.
 type Runtime struct {
-	parseLiteral func(lit String) (Object, error)
-	Stack        []Frame `json:"stack,omitempty"`
+	Stack      []*Env           `json:"stack,omitempty"` // environments of the calls being evaluated, Stack[0] is global
+	trace      []LambdaExpr     // lambda calls being evaluated, from outermost to innermost
+	SearchPath []string         `json:"search_path,omitempty"` // directories to look for imported files
+	imported   *importCache     // shared with forks
+	regexps    *regexpCache     // shared with forks
+	importing  []*importFile    // files being imported, to detect import cycles
+	shared     map[*Env]int     // environments shared with forks or a parent, copied before writing, number of forks sharing them
+	parent     *Runtime         // runtime this one was forked from, nil once released
+	forked     []*Env           // environments shared with parent
+	mu         sync.Mutex       // protects shared and imported during Fork and Release
+	Limits     Limits           `json:"limits"`
+	usage      *usage           // resources used by the current evaluation
+	depth      int              // number of nested Step calls, 0 between evaluations
+	Stdout     io.Writer        `json:"-"` // output of print and println
+	Stderr     io.Writer        `json:"-"` // output of eprint
+	Stdin      io.Reader        `json:"-"` // input of read-line and read-all, buffered and shared with forks
+	clock      func() time.Time // clock of time, time.Now if nil
 }
 type Frame map[String]Object
 
@@ -20,147 +46,370 @@ func (f Frame) Update(otherFrame Frame) Frame {
 	return f
 }
 
+// Env : variables of a function call, enclosed by the environment the function was defined in
+//
+// names are looked up along Parent then in the global frame, a nil Parent stands for the global frame
+type Env struct {
+	Frame  Frame `json:"frame,omitempty"`
+	Parent *Env  `json:"parent,omitempty"`
+}
+
+// writableEnv : i-th environment on stack, copied first if it is shared (copy-on-write)
+func (r *Runtime) writableEnv(i int) *Env {
+	r.mu.Lock()
+	_, shared := r.shared[r.Stack[i]]
+	r.mu.Unlock()
+	if shared {
+		r.Stack[i] = &Env{
+			Frame:  make(Frame).Update(r.Stack[i].Frame),
+			Parent: r.Stack[i].Parent,
+		}
+	}
+	return r.Stack[i]
+}
+
+func (r *Runtime) writableFrame(i int) Frame {
+	return r.writableEnv(i).Frame
+}
+
+// closure : environment captured by a lambda defined now, nil at top level
+func (r *Runtime) closure() *Env {
+	if len(r.Stack) == 1 {
+		return nil
+	}
+	return r.writableEnv(len(r.Stack) - 1)
+}
+
 func (r *Runtime) LoadModule(m Module) *Runtime {
-	r.Stack[0][m.Name] = m
+	r.writableFrame(0)[m.Name] = m
 	return r
 }
 
+// SetGlobal : assign a value to a global variable
+func (r *Runtime) SetGlobal(name String, o Object) *Runtime {
+	r.writableFrame(0)[name] = o
+	return r
+}
+
+// Fork : child Runtime for another goroutine, see the concurrency contract of Runtime
+//
+// a fork made during an evaluation continues it, its usage counts toward the Limits of the evaluation
+func (r *Runtime) Fork() *Runtime {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.shared == nil {
+		r.shared = make(map[*Env]int)
+	}
+	if r.imported == nil {
+		r.imported = &importCache{}
+	}
+	if r.regexps == nil {
+		r.regexps = &regexpCache{}
+	}
+	if _, ok := r.Stdin.(*sharedInput); !ok && r.Stdin != nil {
+		r.Stdin = newSharedInput(r.Stdin)
+	}
+	shared := make(map[*Env]int, len(r.Stack))
+	for _, e := range r.Stack {
+		r.shared[e]++
+		shared[e] = 1
+	}
+	child := &Runtime{
+		Stack:      slices.Clone(r.Stack),
+		trace:      slices.Clone(r.trace),
+		SearchPath: r.SearchPath,
+		imported:   r.imported,
+		regexps:    r.regexps,
+		importing:  cloneImporting(r.importing),
+		shared:     shared,
+		parent:     r,
+		forked:     slices.Clone(r.Stack),
+		Limits:     r.Limits,
+		Stdout:     r.Stdout,
+		Stderr:     r.Stderr,
+		Stdin:      r.Stdin,
+		clock:      r.clock,
+	}
+	if r.depth > 0 {
+		child.usage, child.depth = r.usage, r.depth
+	}
+	return child
+}
+
+// Release : the fork is done, the parent no longer copies the environments it shared with the fork before writing them
+//
+// the fork must not be used afterward, releasing twice or releasing a Runtime that is not a fork does nothing
+func (r *Runtime) Release() {
+	p := r.parent
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, e := range r.forked {
+		if p.shared[e]--; p.shared[e] <= 0 {
+			delete(p.shared, e)
+		}
+	}
+	r.parent, r.forked = nil, nil
+}
+
 const (
 	SIMPLE_DETECT_NONPURE = false
-	MAX_STACK_DEPTH       = 1000
+	MAX_STACK_DEPTH       = 10000 // default of Limits.MaxStackDepth, non-tail recursion about 5000 calls deep
+	MAX_MACRO_EXPANSIONS  = 1000  // expansions of macro calls into macro calls before giving up
 	TAILCALL_OPTIMIZATION = true
 )
 
+// searchOnStack : look up a name in the environment chain of the current call then in the global frame
 func (r *Runtime) searchOnStack(name String) (Object, error) {
-	for i := len(r.Stack) - 1; i >= 0; i-- {
-		if o, ok := r.Stack[i][name]; ok {
+	top := r.Stack[len(r.Stack)-1]
+	for e := top; e != nil && e != r.Stack[0]; e = e.Parent {
+		if o, ok := e.Frame[name]; ok {
 			if SIMPLE_DETECT_NONPURE {
-				if i != 0 && i < len(r.Stack)-1 {
+				if e != top {
 					_, _ = fmt.Fprintf(os.Stderr, "non-pure function")
 				}
 			}
 			return o, nil
 		}
 	}
-	return nil, fmt.Errorf("object not found %s", name)
+	if o, ok := r.Stack[0].Frame[name]; ok {
+		return o, nil
+	}
+	return nil, newError(ErrorUnboundName, "object not found %s", name)
 }
 
 var InterruptError = errors.New("interrupt")
 var TimeoutError = errors.New("timeout")
 var StackOverflowError = errors.New("stack overflow")
 
-type stepOptions struct {
-	tailCall bool
-}
-
-func getOptionsFromContext(ctx context.Context) (*stepOptions, bool) {
-	if o, ok := ctx.Value("step_options").(*stepOptions); ok {
-		return o, true
-	}
-	// default option
-	return &stepOptions{
-		tailCall: false,
-	}, false
-}
-
-func setOptionsToContext(ctx context.Context, o *stepOptions) context.Context {
-	return context.WithValue(ctx, "step_options", o)
-}
-
-// Step -
+// Step - evaluate an expression, errors are wrapped with the span of the failed expression
 func (r *Runtime) Step(ctx context.Context, expr Expr) (Object, error) {
-	if ctx.Err() != nil {
-		return nil, ctx.Err()
+	if r.depth == 0 || r.usage == nil {
+		// new evaluation
+		r.usage = &usage{}
 	}
+	r.depth++
+	v, err := r.step(ctx, expr)
+	r.depth--
+	if err != nil {
+		return nil, r.wrapError(expr, err)
+	}
+	return v, nil
+}
 
-	options, _ := getOptionsFromContext(ctx)
-
-	deadline, ok := ctx.Deadline()
-	if ok && time.Now().After(deadline) {
-		return nil, TimeoutError
+// unwind : pop the environments and trace entries pushed since stack and trace had these sizes
+//
+// a module like kaboom may have popped more than that, nothing is pushed back then
+func (r *Runtime) unwind(stackSize int, traceSize int) {
+	if len(r.Stack) > stackSize {
+		r.Stack = r.Stack[:stackSize]
 	}
-	if len(r.Stack) > MAX_STACK_DEPTH {
-		return nil, StackOverflowError
+	if len(r.trace) > traceSize {
+		r.trace = r.trace[:traceSize]
 	}
-	select {
-	case <-ctx.Done():
-		return nil, InterruptError
-	default:
-		switch expr := expr.(type) {
+}
+
+// step : evaluate an expression as a trampoline, a call in tail position replaces the environment of the current call
+//
+// tail positions are lambda bodies, macro expansions and the expressions returned by Module.Tail (tail, case, if)
+func (r *Runtime) step(ctx context.Context, expr Expr) (Object, error) {
+	// environments and trace entries pushed by this step are popped when it returns
+	stackSize, traceSize := len(r.Stack), len(r.trace)
+	defer r.unwind(stackSize, traceSize)
+	expansions := 0 // consecutive macro expansions
+	for {
+		if err := ctx.Err(); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, TimeoutError
+			}
+			return nil, InterruptError
+		}
+		if err := r.count(); err != nil {
+			return nil, err
+		}
+		switch e := expr.(type) {
 		case NameExpr:
-			var v Object
-			// parse name
-			v, err := r.parseLiteral(String(expr))
-			if err == nil {
-				return v, nil
+			// find in stack for variable, literals are ValueExpr since parsing
+			v, err := r.searchOnStack(String(e.Name))
+			if err != nil {
+				return nil, r.wrapError(e, err)
+			}
+			return v, nil
+
+		case ValueExpr:
+			if s, ok := e.Value.(String); ok {
+				if err := r.Limits.checkSize(len(s)); err != nil {
+					return nil, r.wrapError(e, err)
+				}
 			}
-			// find in stack for variable
-			return r.searchOnStack(String(expr))
+			return e.Value, nil
 
 		case LambdaExpr:
-			f, err := r.searchOnStack(String(expr.Name))
+			f, err := r.searchOnStack(String(e.Name.Name))
 			if err != nil {
-				return nil, err
+				return nil, r.wrapError(e, err)
 			}
 			switch f := f.(type) {
 			case Lambda:
 				// 1. evaluate arguments
-				args, err := r.stepMany(ctx, expr.Args...)
+				args, err := r.stepArgs(ctx, e)
 				if err != nil {
-					return nil, err
+					return nil, r.wrapError(e, err)
 				}
 				// 2. add argument to local Frame
-				localFrame := make(Frame).Update(f.Frame)
-				for i := 0; i < len(f.Params); i++ {
-					localFrame[f.Params[i]] = args[i]
+				localFrame, err := f.frame(args)
+				if err != nil {
+					return nil, r.wrapError(e, err)
+				}
+				// 3. push Frame to Stack, a tail call replaces the Frame pushed by a previous iteration
+				if err := r.allocate(1); err != nil {
+					return nil, r.wrapError(e, err)
 				}
-				// 3. push Frame to Stack
-				if options.tailCall {
-					r.Stack[len(r.Stack)-1].Update(localFrame)
+				env := &Env{Frame: localFrame, Parent: f.Env}
+				if TAILCALL_OPTIMIZATION && len(r.Stack) > stackSize {
+					r.Stack[len(r.Stack)-1] = env
+					r.trace[len(r.trace)-1] = e
 				} else {
-					r.Stack = append(r.Stack, localFrame)
+					r.Stack = append(r.Stack, env)
+					r.trace = append(r.trace, e)
+				}
+				if err := r.bindDefaults(ctx, f, len(args)); err != nil {
+					return nil, r.wrapError(e, err)
+				}
+				// 4. exec function body in tail position
+				expr = f.Impl
+				expansions = 0
+			case Module:
+				if f.Tail == nil {
+					v, err := f.Exec(ctx, r, e)
+					if err == nil {
+						err = r.account(v)
+					}
+					if err != nil {
+						return nil, r.wrapError(e, err)
+					}
+					return v, nil
 				}
-				// 4. exec function
-				v, err := r.Step(ctx, f.Impl)
+				next, err := f.Tail(ctx, r, e)
 				if err != nil {
-					return nil, err
+					return nil, r.wrapError(e, err)
 				}
-				// 5. pop Frame from Stack
-				if !options.tailCall {
-					r.Stack = r.Stack[:len(r.Stack)-1]
+				expr = next
+				expansions = 0
+			case Macro:
+				// the expansion is evaluated in place of the call
+				if expansions++; expansions > MAX_MACRO_EXPANSIONS {
+					return nil, r.wrapError(e, tooManyExpansions())
 				}
-				return v, nil
-			case Module:
-				return f.Exec(ctx, r, expr)
+				next, err := r.expand(ctx, e, f)
+				if err != nil {
+					return nil, r.wrapError(e, err)
+				}
+				expr = next
 			default:
-				return nil, fmt.Errorf("function or module %s found but wrong type %s", expr.Name.String(), f.String())
+				return nil, r.wrapError(e, newError(ErrorTypeMismatch, "function or module %s found but wrong type %s", e.Name.String(), f.String()))
 			}
 		default:
-			return nil, fmt.Errorf("runtime error: unknown expression type")
+			return nil, newError(ErrorRuntime, "unknown expression type")
 		}
 	}
 }
 
+// checkArity : error unless n arguments bind every required param of f
+func (f Lambda) checkArity(n int) error {
+	required := len(f.Params) - len(f.Defaults)
+	switch {
+	case f.Rest != "" && n < required:
+		return newError(ErrorArity, "function requires at least %d arguments but got %d", required, n)
+	case f.Rest == "" && len(f.Defaults) == 0 && n != required:
+		return newError(ErrorArity, "function requires %d arguments but got %d", required, n)
+	case f.Rest == "" && (n < required || n > len(f.Params)):
+		return newError(ErrorArity, "function requires %d to %d arguments but got %d", required, len(f.Params), n)
+	default:
+		return nil
+	}
+}
+
+// frame : local Frame of a call of f, optional params without argument are bound later by bindDefaults
+func (f Lambda) frame(args []Object) (Frame, error) {
+	if err := f.checkArity(len(args)); err != nil {
+		return nil, err
+	}
+	localFrame := make(Frame)
+	for i := 0; i < len(f.Params) && i < len(args); i++ {
+		localFrame[f.Params[i]] = args[i]
+	}
+	if f.Rest != "" {
+		rest := List{}
+		if len(args) > len(f.Params) {
+			rest = slices.Clone(args[len(f.Params):])
+		}
+		localFrame[f.Rest] = rest
+	}
+	return localFrame, nil
+}
+
+// bindDefaults : evaluate the default values of the optional params of f not given by n arguments, in the call on top of stack
+func (r *Runtime) bindDefaults(ctx context.Context, f Lambda, n int) error {
+	required := len(f.Params) - len(f.Defaults)
+	for i := max(n, required); i < len(f.Params); i++ {
+		v, err := r.Step(ctx, f.Defaults[i-required])
+		if err != nil {
+			return err
+		}
+		r.writableFrame(len(r.Stack) - 1)[f.Params[i]] = v
+	}
+	return nil
+}
+
 func (r *Runtime) stepMany(ctx context.Context, exprList ...Expr) ([]Object, error) {
 	var outputs []Object
-	if len(exprList) != 0 {
-		for i, expr := range exprList {
-			if TAILCALL_OPTIMIZATION {
-				if i == len(exprList)-1 && len(exprList) >= 2 { // TODO somehow if exprList is of length 1 then error
-					options, found := getOptionsFromContext(ctx)
-					options.tailCall = true
-					if !found {
-						ctx = setOptionsToContext(ctx, options)
-					}
-				}
-			}
-			v, err := r.Step(ctx, expr)
-			if err != nil {
-				return nil, err
-			}
-			outputs = append(outputs, v)
+	for _, expr := range exprList {
+		v, err := r.Step(ctx, expr)
+		if err != nil {
+			return nil, err
 		}
-
+		outputs = append(outputs, v)
 	}
 	return outputs, nil
 }
+
+// call : apply a Lambda or a Module to evaluated arguments, site is the expression that makes the call
+func (r *Runtime) call(ctx context.Context, site LambdaExpr, f Object, args ...Object) (Object, error) {
+	defer r.unwind(len(r.Stack), len(r.trace))
+	switch f := f.(type) {
+	case Lambda:
+		// 1. add argument to local Frame
+		localFrame, err := f.frame(args)
+		if err != nil {
+			return nil, err
+		}
+		// 2. push Frame to Stack
+		r.Stack = append(r.Stack, &Env{Frame: localFrame, Parent: f.Env})
+		r.trace = append(r.trace, site)
+		// 3. exec function, the Frame is popped by unwind
+		if err := r.bindDefaults(ctx, f, len(args)); err != nil {
+			return nil, err
+		}
+		return r.Step(ctx, f.Impl)
+	case Module:
+		// modules take expressions - bind arguments to names in a temporary Frame
+		localFrame := make(Frame)
+		var argExprs []Expr
+		for i, arg := range args {
+			name := fmt.Sprintf("__arg_%d", i)
+			localFrame[String(name)] = arg
+			argExprs = append(argExprs, NameExpr{Name: name, Span: site.Span})
+		}
+		r.Stack = append(r.Stack, &Env{Frame: localFrame, Parent: r.closure()})
+		return f.Exec(ctx, r, LambdaExpr{
+			Name: NameExpr{Name: string(f.Name), Span: site.Span},
+			Args: argExprs,
+			Span: site.Span,
+		})
+	default:
+		return nil, newError(ErrorTypeMismatch, "%s is not a function", getType(f))
+	}
+}
@@ -0,0 +1,161 @@
+package fp
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestTailCall : tail calls through if, case and tail run in constant stack, checked with a small MaxStackDepth
+func TestTailCall(t *testing.T) {
+	n := 1000000
+	if testing.Short() {
+		n = 10000
+	}
+	for _, c := range []struct {
+		name string
+		code string
+		want Object
+	}{
+		{"self if", `(let loop (lambda n acc (if (eq n 0) acc (loop (sub n 1) (add acc 1))))) (loop %d 0)`, Int(n)},
+		{"self case", `(let loop (lambda n (case n 0 "done" _ (loop (sub n 1))))) (loop %d)`, String("done")},
+		{"self tail", `(let loop (lambda n (tail (let m (sub n 1)) (case m 0 "done" _ (loop m))))) (loop %d)`, String("done")},
+		{"mutual if", `
+			(let even (lambda n (if (eq n 0) true (odd (sub n 1)))))
+			(let odd (lambda n (if (eq n 0) false (even (sub n 1)))))
+			(even %d)
+		`, Bool(true)},
+		{"mutual case", `
+			(let even (lambda n (case n 0 true _ (odd (sub n 1)))))
+			(let odd (lambda n (case n 0 false _ (even (sub n 1)))))
+			(even %d)
+		`, Bool(true)},
+		{"mutual tail", `
+			(let ping (lambda n (tail (let m (sub n 1)) (if (eq m 0) "ping" (pong m)))))
+			(let pong (lambda n (tail (let m (sub n 1)) (if (eq m 0) "pong" (ping m)))))
+			(ping %d)
+		`, String("pong")},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := NewStdRuntime()
+			r.Limits.MaxStackDepth = 16
+			expect(t, r, fmt.Sprintf(c.code, n), c.want)
+			if len(r.Stack) != 1 || len(r.trace) != 0 {
+				t.Errorf("stack was not unwound: %d environments, %d calls", len(r.Stack), len(r.trace))
+			}
+		})
+	}
+}
+
+// TestNonTailCall : calls that are not in tail position still nest and hit MaxStackDepth
+func TestNonTailCall(t *testing.T) {
+	r := NewStdRuntime()
+	expect(t, r, `(let fib (lambda x (case x 0 0 1 1 _ (add (fib (sub x 1)) (fib (sub x 2)))))) (fib 15)`, Int(610))
+	expectError(t, r, `(let sum (lambda n (if (eq n 0) 0 (add n (sum (sub n 1)))))) (sum 100000)`, ErrorStackOverflow)
+	expect(t, r, `(sum 100)`, Int(5050))
+	expect(t, r, `(let count (lambda n (case n 0 0 _ (add 1 (count (sub n 1)))))) (count 2000)`, Int(2000))
+}
+
+const paramsDefs = `
+(let f (lambda x (y (mul x 2)) & rest (list x y rest)))
+(let g (lambda x y (add x y)))
+`
+
+// TestParams : optional params take their default value, rest params collect extra arguments
+func TestParams(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(f 1)`, List{Int(1), Int(2), List{}}},
+		{`(f 1 5 6 7)`, List{Int(1), Int(5), ints(6, 7)}},
+		{`(g * (list 1 2))`, Int(3)},
+		{`(map (list 1 2) f)`, List{List{Int(1), Int(2), List{}}, List{Int(2), Int(4), List{}}}},
+		{`(let sum (lambda & xs (case (len xs) 0 0 _ (add (peek xs 1) (sum * (drop xs 1)))))) (sum 1 2 3)`, Int(6)},
+		{`(let h (lambda (x 1) (y (add x 1)) (list x y))) (list (h) (h 5) (h 5 0))`, List{ints(1, 2), ints(5, 6), ints(5, 0)}},
+	}
+	for _, c := range tests {
+		r := NewStdRuntime()
+		mustEval(t, r, paramsDefs)
+		expect(t, r, c.code, c.want)
+	}
+}
+
+// TestParamsErrors : arity is checked against required, optional and rest params
+func TestParamsErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(g 1)`, ErrorArity},
+		{`(g 1 2 3)`, ErrorArity},
+		{`(f)`, ErrorArity},
+		{`(map (list 1) g)`, ErrorArity},
+		{`(lambda (x 1) y x)`, ErrorTypeMismatch},
+		{`(lambda x & y)`, ErrorTypeMismatch},
+		{`(lambda x &)`, ErrorTypeMismatch},
+		{`(lambda &)`, ErrorTypeMismatch},
+		{`(lambda & & x)`, ErrorTypeMismatch},
+		{`(lambda x & y z x)`, ErrorTypeMismatch},
+	}
+	for _, c := range tests {
+		r := NewStdRuntime()
+		mustEval(t, r, paramsDefs)
+		expectError(t, r, c.code, c.kind)
+	}
+}
+
+// TestLexicalScope : names resolve in the environment where a lambda is defined, not where it is called
+func TestLexicalScope(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+		want Object
+	}{
+		{"recursive local function", `
+			(let f (lambda n (tail
+				(let g (lambda k acc (case k 0 acc _ (g (sub k 1) (add acc k)))))
+				(g n 0)
+			)))
+			(f 100)
+		`, Int(5050)},
+		{"capture two scopes out", `
+			(let make (lambda a (tail
+				(let mid (lambda b (lambda c (list a b c))))
+				mid
+			)))
+			(let m (make 1))
+			(let h (m 2))
+			(let a 10)
+			(h 3)
+		`, ints(1, 2, 3)},
+		{"counters do not share state", `
+			(let counter (lambda start (lambda step (add start step))))
+			(let c1 (counter 10))
+			(let c2 (counter 20))
+			(list (c1 1) (c2 1))
+		`, ints(11, 21)},
+		{"global rebound after definition", `
+			(let x 1)
+			(let f (lambda y (add x y)))
+			(let x 2)
+			(f 0)
+		`, Int(2)},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			expect(t, NewStdRuntime(), c.code, c.want)
+		})
+	}
+}
+
+// TestLexicalScopeHidesCaller : a callee does not see the locals of its caller
+func TestLexicalScopeHidesCaller(t *testing.T) {
+	tests := []string{
+		`(let callee (lambda n (add n secret))) (let caller (lambda secret (callee 1))) (caller 5)`,
+		`(let callee (lambda n (add n local))) (let caller (lambda n (tail (let local 1) (callee n)))) (caller 5)`,
+		`(let caller (lambda secret (map (list 1) (lambda x (peek-secret x))))) (let peek-secret (lambda x secret)) (caller 5)`,
+	}
+	for _, code := range tests {
+		expectError(t, NewStdRuntime(), code, ErrorUnboundName)
+	}
+}
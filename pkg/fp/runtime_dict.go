@@ -0,0 +1,200 @@
+package fp
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// compareKeys : total order on any objects, ordered values by value then by type then by string
+func compareKeys(a Object, b Object) int {
+	if c, ok := compare(a, b); ok {
+		return c
+	}
+	if c := strings.Compare(string(getType(a)), string(getType(b))); c != 0 {
+		return c
+	}
+	return strings.Compare(a.String(), b.String())
+}
+
+// sortedEntries : entries sorted by key so that output is deterministic
+func (d Dict) sortedEntries() []DictEntry {
+	entries := d.Entries()
+	slices.SortStableFunc(entries, func(x DictEntry, y DictEntry) int {
+		return compareKeys(x.Key, y.Key)
+	})
+	return entries
+}
+
+func dictArg(name String, o Object) (Dict, error) {
+	d, ok := o.(Dict)
+	if !ok {
+		return Dict{}, newError(ErrorTypeMismatch, "%s requires a dict but got %s", name, getType(o))
+	}
+	return d, nil
+}
+
+var dictExtension = Extension{
+	Name: "dict",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values)%2 != 0 {
+			return nil, newError(ErrorArity, "dict requires key value pairs")
+		}
+		var d Dict
+		for i := 0; i < len(values); i += 2 {
+			d.Set(values[i], values[i+1])
+		}
+		return d, nil
+	},
+	Man: "module: (dict \"a\" 1 \"b\" 2) - make a dict from key value pairs, (dict * d) copies d",
+}
+
+var getExtension = Extension{
+	Name: "get",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 && len(values) != 3 {
+			return nil, newError(ErrorArity, "get requires 2 or 3 arguments")
+		}
+		d, err := dictArg("get", values[0])
+		if err != nil {
+			return nil, err
+		}
+		if v, ok := d.Get(values[1]); ok {
+			return v, nil
+		}
+		if len(values) == 3 {
+			return values[2], nil
+		}
+		return nil, newError(ErrorRuntime, "key %s not found", values[1])
+	},
+	Man: "module: (get d \"a\" 0) - get value of key \"a\" in d, return default 0 if not found",
+}
+
+var setExtension = Extension{
+	Name: "set",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) < 1 || len(values)%2 != 1 {
+			return nil, newError(ErrorArity, "set requires a dict and key value pairs")
+		}
+		d, err := dictArg("set", values[0])
+		if err != nil {
+			return nil, err
+		}
+		d = d.Clone()
+		for i := 1; i < len(values); i += 2 {
+			d.Set(values[i], values[i+1])
+		}
+		return d, nil
+	},
+	Man: "module: (set d \"a\" 1 \"b\" 2) - return a new dict with keys set, d is unchanged",
+}
+
+var hasExtension = Extension{
+	Name: "has",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 {
+			return nil, newError(ErrorArity, "has requires 2 arguments")
+		}
+		d, err := dictArg("has", values[0])
+		if err != nil {
+			return nil, err
+		}
+		_, ok := d.Get(values[1])
+		return Bool(ok), nil
+	},
+	Man: "module: (has d \"a\") - true if key \"a\" is in d",
+}
+
+var removeExtension = Extension{
+	Name: "remove",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) < 1 {
+			return nil, newError(ErrorArity, "remove requires at least 1 argument")
+		}
+		d, err := dictArg("remove", values[0])
+		if err != nil {
+			return nil, err
+		}
+		d = d.Clone()
+		for _, key := range values[1:] {
+			d.Delete(key)
+		}
+		return d, nil
+	},
+	Man: "module: (remove d \"a\" \"b\") - return a new dict without keys, d is unchanged",
+}
+
+var keysExtension = Extension{
+	Name: "keys",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "keys requires 1 argument")
+		}
+		d, err := dictArg("keys", values[0])
+		if err != nil {
+			return nil, err
+		}
+		var l List
+		for _, entry := range d.sortedEntries() {
+			l = append(l, entry.Key)
+		}
+		return l, nil
+	},
+	Man: "module: (keys d) - sorted list of keys",
+}
+
+var valuesExtension = Extension{
+	Name: "values",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "values requires 1 argument")
+		}
+		d, err := dictArg("values", values[0])
+		if err != nil {
+			return nil, err
+		}
+		var l List
+		for _, entry := range d.sortedEntries() {
+			l = append(l, entry.Value)
+		}
+		return l, nil
+	},
+	Man: "module: (values d) - list of values ordered by key",
+}
+
+var itemsExtension = Extension{
+	Name: "items",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "items requires 1 argument")
+		}
+		d, err := dictArg("items", values[0])
+		if err != nil {
+			return nil, err
+		}
+		var l List
+		for _, entry := range d.sortedEntries() {
+			l = append(l, List{entry.Key, entry.Value})
+		}
+		return l, nil
+	},
+	Man: "module: (items d) - list of [key, value] ordered by key",
+}
+
+var mergeExtension = Extension{
+	Name: "merge",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		var output Dict
+		for _, v := range values {
+			d, err := dictArg("merge", v)
+			if err != nil {
+				return nil, err
+			}
+			for _, entry := range d.Entries() {
+				output.Set(entry.Key, entry.Value)
+			}
+		}
+		return output, nil
+	},
+	Man: "module: (merge d1 d2) - return a new dict with keys of all dicts, later dicts take precedence",
+}
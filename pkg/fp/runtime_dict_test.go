@@ -0,0 +1,48 @@
+package fp
+
+import (
+	"testing"
+)
+
+// TestDict : construction, lookup and functional updates, the original dict is unchanged
+func TestDict(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(type (dict))`, String("Dict")},
+		{`(get (dict "a" 1 "b" 2) "b")`, Int(2)},
+		{`(get (dict "a" 1) "z" 0)`, Int(0)},
+		{`(has (dict "a" 1) "a")`, Bool(true)},
+		{`(has (dict "a" 1) "b")`, Bool(false)},
+		{`(let d (dict "a" 1)) (let e (set d "a" 2 "b" 3)) (list (get d "a") (get e "a") (get e "b"))`, List{Int(1), Int(2), Int(3)}},
+		{`(let d (dict "a" 1 "b" 2)) (let e (remove d "a" "z")) (list (has d "a") (has e "a") (len e))`, List{Bool(true), Bool(false), Int(1)}},
+		{`(keys (dict "b" 1 "a" 2 1 3))`, List{Int(1), String("a"), String("b")}},
+		{`(values (dict "b" 1 "a" 2))`, List{Int(2), Int(1)}},
+		{`(items (dict "b" 1 "a" 2))`, List{List{String("a"), Int(2)}, List{String("b"), Int(1)}}},
+		{`(merge (dict "a" 1 "b" 1) (dict "b" 2) (dict "c" 3))`, dictOf(String("a"), Int(1), String("b"), Int(2), String("c"), Int(3))},
+		{`(let d (dict "a" 1)) (let e (dict * d)) (list (eq e d) (get e "a"))`, List{Bool(true), Int(1)}},
+		{`(str (dict "b" 1 "a" 2))`, String("{a -> 2,b -> 1,}")},
+	}
+	for _, c := range tests {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+// TestDictErrors : odd pairs and non-dict arguments
+func TestDictErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(dict "a")`, ErrorArity},
+		{`(get (dict) "a" 1 2)`, ErrorArity},
+		{`(get (dict "a" 1) "b")`, ErrorRuntime},
+		{`(get (list 1) 1)`, ErrorTypeMismatch},
+		{`(keys "a")`, ErrorTypeMismatch},
+		{`(merge (dict) 1)`, ErrorTypeMismatch},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
@@ -0,0 +1,157 @@
+package fp
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"math"
+	"math/big"
+	"reflect"
+)
+
+// Equal : structural equality
+//
+// numbers are equal if they have the same value whatever their types, lists and dicts are equal if their elements are,
+// lambdas and macros are equal if they are defined by the same expression in the same environment, modules if they have the same name,
+// regexes if they have the same pattern, quoted expressions if they are written the same
+func Equal(a Object, b Object) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	switch a := a.(type) {
+	case Bool:
+		b, ok := b.(Bool)
+		return ok && a == b
+	case Fp:
+		b, ok := b.(Fp)
+		return ok && a.P.Cmp(b.P) == 0 && a.Value.Cmp(b.Value) == 0
+	case List:
+		b, ok := b.(List)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for i := range a {
+			if !Equal(a[i], b[i]) {
+				return false
+			}
+		}
+		return true
+	case Dict:
+		b, ok := b.(Dict)
+		if !ok || a.Len() != b.Len() {
+			return false
+		}
+		for _, entry := range a.Entries() {
+			v, ok := b.Get(entry.Key)
+			if !ok || !Equal(entry.Value, v) {
+				return false
+			}
+		}
+		return true
+	case Lambda:
+		b, ok := b.(Lambda)
+		return ok && a.Env == b.Env && exprSpan(a.Impl) == exprSpan(b.Impl) && a.String() == b.String()
+	case Module:
+		b, ok := b.(Module)
+		return ok && a.Name == b.Name
+	case Macro:
+		b, ok := b.(Macro)
+		return ok && Equal(a.Transformer, b.Transformer)
+	case Wildcard:
+		_, ok := b.(Wildcard)
+		return ok
+	case Unwrap:
+		_, ok := b.(Unwrap)
+		return ok
+	case Regex:
+		b, ok := b.(Regex)
+		return ok && a.Value.String() == b.Value.String()
+	case Quoted:
+		b, ok := b.(Quoted)
+		return ok && a.Expr.String() == b.Expr.String()
+	case Symbol:
+		b, ok := b.(Symbol)
+		return ok && a == b
+	default:
+		c, ok := compare(a, b)
+		return ok && c == 0
+	}
+}
+
+// Hash : hash consistent with Equal, Equal(a, b) implies Hash(a) == Hash(b)
+func Hash(o Object) uint64 {
+	h := fnv.New64a()
+	writeUint := func(tag byte, v uint64) {
+		b := make([]byte, 9)
+		b[0] = tag
+		binary.LittleEndian.PutUint64(b[1:], v)
+		_, _ = h.Write(b)
+	}
+	writeBytes := func(tag byte, b []byte) {
+		_, _ = h.Write([]byte{tag})
+		_, _ = h.Write(b)
+	}
+	writeBig := func(v *big.Int) {
+		if v.IsInt64() {
+			writeUint('i', uint64(v.Int64()))
+			return
+		}
+		writeBytes(byte('b'+v.Sign()), v.Bytes())
+	}
+	switch o := o.(type) {
+	case nil:
+		writeUint('0', 0)
+	case Int:
+		writeUint('i', uint64(o))
+	case BigInt:
+		writeBig(o.Value)
+	case Float:
+		f := float64(o)
+		if f == math.Trunc(f) && !math.IsInf(f, 0) {
+			// integral floats are equal to integers
+			v, _ := big.NewFloat(f).Int(nil)
+			writeBig(v)
+		} else {
+			writeUint('f', math.Float64bits(f))
+		}
+	case Fp:
+		writeBig(o.P)
+		writeBig(o.Value)
+		writeUint('p', 0)
+	case Bool:
+		if o {
+			writeUint('t', 1)
+		} else {
+			writeUint('t', 0)
+		}
+	case String:
+		writeBytes('s', []byte(o))
+	case List:
+		writeUint('l', uint64(len(o)))
+		for _, elem := range o {
+			writeUint('e', Hash(elem))
+		}
+	case Dict:
+		// order of entries does not matter
+		var sum uint64
+		for _, entry := range o.Entries() {
+			sum += Hash(entry.Key)*31 + Hash(entry.Value)
+		}
+		writeUint('d', sum)
+	case Lambda:
+		writeUint('c', uint64(reflect.ValueOf(o.Env).Pointer()))
+		writeBytes('c', []byte(o.String()))
+	case Module:
+		writeBytes('m', []byte(o.Name))
+	case Macro:
+		writeUint('M', Hash(o.Transformer))
+	case Regex:
+		writeBytes('r', []byte(o.Value.String()))
+	case Quoted:
+		writeBytes('q', []byte(o.Expr.String()))
+	case Symbol:
+		writeBytes('y', []byte(o))
+	default:
+		writeBytes('?', []byte(getType(o)))
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,96 @@
+package fp
+
+import (
+	"testing"
+)
+
+// dictOf : Dict from key value pairs
+func dictOf(kv ...Object) Dict {
+	var d Dict
+	for i := 0; i+1 < len(kv); i += 2 {
+		d.Set(kv[i], kv[i+1])
+	}
+	return d
+}
+
+// TestEqualHash : structural equality, equal objects have the same hash
+func TestEqualHash(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Object
+		equal bool
+	}{
+		{"int float", Int(1), Float(1), true},
+		{"int big", Int(5), bigInt("5"), true},
+		{"big float", bigInt("9223372036854775808"), Float(9223372036854775808), true},
+		{"float fraction", Float(0.5), Float(0.5), true},
+		{"int string", Int(1), String("1"), false},
+		{"nil", nil, nil, true},
+		{"nil list", nil, List{}, false},
+		{"fp", fp(3, 7), fp(10, 7), true},
+		{"fp modulus", fp(1, 7), fp(1, 5), false},
+		{"fp int", fp(1, 7), Int(1), false},
+		{"list", List{Int(1), List{String("a")}}, List{Float(1), List{String("a")}}, true},
+		{"list length", List{Int(1)}, List{Int(1), Int(1)}, false},
+		{"dict order", dictOf(String("a"), Int(1), String("b"), Int(2)), dictOf(String("b"), Int(2), String("a"), Int(1)), true},
+		{"dict value", dictOf(String("a"), Int(1)), dictOf(String("a"), Int(2)), false},
+		{"nested dict", List{dictOf(List{Int(1)}, Bool(true))}, List{dictOf(List{Int(1)}, Bool(true))}, true},
+		{"bool", Bool(true), Bool(true), true},
+		{"bool int", Bool(true), Int(1), false},
+		{"symbol string", Symbol("x"), String("x"), false},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			if got := Equal(c.a, c.b); got != c.equal {
+				t.Errorf("Equal(%v, %v): got %v want %v", c.a, c.b, got, c.equal)
+			}
+			if got := Equal(c.b, c.a); got != c.equal {
+				t.Errorf("Equal(%v, %v): got %v want %v", c.b, c.a, got, c.equal)
+			}
+			if c.equal && Hash(c.a) != Hash(c.b) {
+				t.Errorf("Hash(%v) != Hash(%v)", c.a, c.b)
+			}
+		})
+	}
+}
+
+// TestDictKeys : keys are looked up by Equal, lists and dicts can be keys
+func TestDictKeys(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(get (dict 1 "int") 1.0)`, String("int")},
+		{`(len (dict 1 "a" 1.0 "b"))`, Int(1)},
+		{`(get (dict (list 1 2) "l") (list 1 2))`, String("l")},
+		{`(get (dict (dict "a" 1) "d") (dict "a" 1))`, String("d")},
+		{`(has (dict (fp 1 7) 0) (fp 1 5))`, Bool(false)},
+		{`(get (dict 99999999999999999999 "big") (add 99999999999999999998 1))`, String("big")},
+		{`(eq (dict "a" (list 1)) (dict "a" (list 1.0)))`, Bool(true)},
+	}
+	for _, c := range tests {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+// TestDictMany : many keys, Set, Get and Delete stay consistent
+func TestDictMany(t *testing.T) {
+	var d Dict
+	for i := 0; i < 10000; i++ {
+		d.Set(List{Int(i), String("k")}, Int(i))
+	}
+	for i := 0; i < 10000; i += 2 {
+		if !d.Delete(List{Int(i), String("k")}) {
+			t.Fatalf("delete %d: not found", i)
+		}
+	}
+	if d.Len() != 5000 {
+		t.Errorf("got %d entries want 5000", d.Len())
+	}
+	for i := 0; i < 10000; i++ {
+		v, ok := d.Get(List{Float(i), String("k")})
+		if ok != (i%2 == 1) || (ok && !Equal(v, Int(i))) {
+			t.Fatalf("get %d: got %v %v", i, v, ok)
+		}
+	}
+}
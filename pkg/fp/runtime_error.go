@@ -0,0 +1,151 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrorKind : category of an evaluation error
+type ErrorKind int
+
+const (
+	ErrorRuntime ErrorKind = iota
+	ErrorUnboundName
+	ErrorTypeMismatch
+	ErrorArity
+	ErrorDivisionByZero
+	ErrorNoCaseMatched
+	ErrorStackOverflow
+	ErrorTimeout
+	ErrorInterrupt
+	ErrorLimitExceeded
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorUnboundName:
+		return "UnboundNameError"
+	case ErrorTypeMismatch:
+		return "TypeMismatchError"
+	case ErrorArity:
+		return "ArityError"
+	case ErrorDivisionByZero:
+		return "DivisionByZeroError"
+	case ErrorNoCaseMatched:
+		return "NoCaseMatchedError"
+	case ErrorStackOverflow:
+		return "StackOverflowError"
+	case ErrorTimeout:
+		return "TimeoutError"
+	case ErrorInterrupt:
+		return "InterruptError"
+	case ErrorLimitExceeded:
+		return "LimitExceededError"
+	default:
+		return "RuntimeError"
+	}
+}
+
+// EvalError : error returned by Runtime.Step
+//
+// Expr is the innermost expression that failed and Trace is the list of lambda calls
+// enclosing it, from outermost to innermost
+type EvalError struct {
+	Kind  ErrorKind    `json:"kind"`
+	Expr  Expr         `json:"expr,omitempty"`
+	Trace []LambdaExpr `json:"trace,omitempty"`
+	Err   error        `json:"-"`
+}
+
+func (e *EvalError) Error() string {
+	if e.Expr == nil || exprSpan(e.Expr).IsZero() {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s: %s", exprSpan(e.Expr).String(), e.Err.Error())
+}
+
+func (e *EvalError) Unwrap() error {
+	return e.Err
+}
+
+// Traceback : python-style traceback, most recent call last
+func (e *EvalError) Traceback() string {
+	s := ""
+	if len(e.Trace) > 0 {
+		s += "Traceback (most recent call last):\n"
+		for i := 0; i < len(e.Trace); {
+			call := e.Trace[i]
+			s += fmt.Sprintf("  %s: %s\n", call.Span.String(), call.String())
+			// collapse repeated calls like deep recursion
+			j := i + 1
+			for j < len(e.Trace) && e.Trace[j].Span == call.Span {
+				j++
+			}
+			if j-i > 1 {
+				s += fmt.Sprintf("  [previous line repeated %d more times]\n", j-i-1)
+			}
+			i = j
+		}
+	}
+	if e.Expr != nil {
+		s += fmt.Sprintf("  %s: %s\n", exprSpan(e.Expr).String(), e.Expr.String())
+	}
+	s += fmt.Sprintf("%s: %s", e.Kind.String(), e.Err.Error())
+	return s
+}
+
+// newError : error of a kind, Step fills in the failing expression and the trace
+func newError(kind ErrorKind, format string, a ...any) error {
+	return &EvalError{
+		Kind: kind,
+		Err:  fmt.Errorf(format, a...),
+	}
+}
+
+func exprSpan(expr Expr) Span {
+	switch expr := expr.(type) {
+	case NameExpr:
+		return expr.Span
+	case LambdaExpr:
+		return expr.Span
+	case ValueExpr:
+		return expr.Span
+	default:
+		return Span{}
+	}
+}
+
+func errorKind(err error) ErrorKind {
+	switch {
+	case errors.Is(err, InterruptError), errors.Is(err, context.Canceled):
+		return ErrorInterrupt
+	case errors.Is(err, TimeoutError), errors.Is(err, context.DeadlineExceeded):
+		return ErrorTimeout
+	case errors.Is(err, StackOverflowError):
+		return ErrorStackOverflow
+	case errors.Is(err, StepLimitError), errors.Is(err, ListLengthLimitError),
+		errors.Is(err, StringSizeLimitError), errors.Is(err, AllocationLimitError), errors.Is(err, IntSizeLimitError):
+		return ErrorLimitExceeded
+	default:
+		return ErrorRuntime
+	}
+}
+
+// wrapError : annotate err with expr and the current trace unless an inner expression already did
+func (r *Runtime) wrapError(expr Expr, err error) error {
+	var evalErr *EvalError
+	if !errors.As(err, &evalErr) {
+		evalErr = &EvalError{
+			Kind: errorKind(err),
+			Err:  err,
+		}
+		err = evalErr
+	}
+	if evalErr.Expr != nil {
+		return err
+	}
+	evalErr.Expr = expr
+	evalErr.Trace = append([]LambdaExpr(nil), r.trace...)
+	return err
+}
@@ -0,0 +1,66 @@
+package fp
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestErrorKinds : each failure is an EvalError of its kind
+func TestErrorKinds(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`x`, ErrorUnboundName},
+		{`(undefined-x 1)`, ErrorUnboundName},
+		{`(add 1 "a")`, ErrorTypeMismatch},
+		{`(let f (lambda x x)) (f 1 2)`, ErrorArity},
+		{`(div 1 0)`, ErrorDivisionByZero},
+		{`(case 3 1 2)`, ErrorNoCaseMatched},
+		{`(let f (lambda n (add 1 (f n)))) (f 1)`, ErrorStackOverflow},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
+
+// TestErrorTrace : the failing expression and the enclosing calls, repeated calls are collapsed in the traceback
+func TestErrorTrace(t *testing.T) {
+	_, err := eval(NewStdRuntime(), `(let g (lambda n (case n 0 (div 1 0) _ (add 1 (g (sub n 1)))))) (g 3)`)
+	var evalErr *EvalError
+	if !errors.As(err, &evalErr) {
+		t.Fatalf("got %v want an EvalError", err)
+	}
+	if got := evalErr.Expr.String(); got != "(div 1 0)" {
+		t.Errorf("expr: got %s want (div 1 0)", got)
+	}
+	if len(evalErr.Trace) != 4 {
+		t.Errorf("trace: got %v want 4 calls", evalErr.Trace)
+	}
+	want := `Traceback (most recent call last):
+  <input>:1:65: (g 3)
+  <input>:1:47: (g (sub n 1))
+  [previous line repeated 2 more times]
+  <input>:1:28: (div 1 0)
+DivisionByZeroError: division by zero`
+	if got := evalErr.Traceback(); got != want {
+		t.Errorf("traceback: got\n%s\nwant\n%s", got, want)
+	}
+	if !errors.Is(err, evalErr.Err) {
+		t.Errorf("EvalError must unwrap to its cause")
+	}
+}
+
+// TestErrorTraceReset : a failed evaluation leaves no trace behind for the next one
+func TestErrorTraceReset(t *testing.T) {
+	r := NewStdRuntime()
+	mustEval(t, r, `(let f (lambda n (add 1 (div 1 n))))`)
+	if _, err := eval(r, `(f 0)`); err == nil {
+		t.Fatal("expected an error")
+	}
+	_, err := eval(r, `(div 2 0)`)
+	var evalErr *EvalError
+	if !errors.As(err, &evalErr) || len(evalErr.Trace) != 0 {
+		t.Errorf("got %v want an EvalError without trace", err)
+	}
+}
@@ -0,0 +1,191 @@
+package fp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// importCache : exports of imported files by absolute path, shared by a Runtime and its forks
+type importCache struct {
+	mu      sync.Mutex
+	modules map[string]Frame
+}
+
+func (c *importCache) get(path string) (Frame, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	exports, ok := c.modules[path]
+	return exports, ok
+}
+
+// put : store exports unless another goroutine was faster, return the stored exports
+func (c *importCache) put(path string, exports Frame) Frame {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.modules == nil {
+		c.modules = make(map[string]Frame)
+	}
+	if cached, ok := c.modules[path]; ok {
+		return cached
+	}
+	c.modules[path] = exports
+	return exports
+}
+
+// importFile : file being imported and the names bound by its own imports, which it does not export
+type importFile struct {
+	path     string
+	imported Frame
+}
+
+// cloneImporting : files being imported, for a fork that records its own imports
+func cloneImporting(importing []*importFile) []*importFile {
+	var clone []*importFile
+	for _, f := range importing {
+		clone = append(clone, &importFile{path: f.path, imported: maps.Clone(f.imported)})
+	}
+	return clone
+}
+
+// resolveImport : find the file to import, relative to the importing file then the search path
+func (r *Runtime) resolveImport(from string, path string) (string, error) {
+	var candidates []string
+	if filepath.IsAbs(path) {
+		candidates = append(candidates, path)
+	} else {
+		dir := "."
+		if from != "" && !strings.HasPrefix(from, "<") { // <stdin>, <input> are not files
+			dir = filepath.Dir(from)
+		}
+		candidates = append(candidates, filepath.Join(dir, path))
+		for _, searchDir := range r.SearchPath {
+			candidates = append(candidates, filepath.Join(searchDir, path))
+		}
+	}
+	for _, candidate := range candidates {
+		if info, err := os.Stat(candidate); err == nil && !info.IsDir() {
+			return filepath.Abs(candidate)
+		}
+	}
+	return "", fmt.Errorf("module %s not found in %s", path, strings.Join(candidates, ", "))
+}
+
+// Import : evaluate a file in a fresh frame and return the top-level bindings it defines, modules are loaded once
+func (r *Runtime) Import(ctx context.Context, from string, path string) (Frame, error) {
+	path, err := r.resolveImport(from, path)
+	if err != nil {
+		return nil, err
+	}
+	if r.imported == nil {
+		r.imported = &importCache{}
+	}
+	if exports, ok := r.imported.get(path); ok {
+		return exports, nil
+	}
+	for i, loading := range r.importing {
+		if loading.path == path {
+			var cycle []string
+			for _, f := range r.importing[i:] {
+				cycle = append(cycle, f.path)
+			}
+			cycle = append(cycle, path)
+			return nil, fmt.Errorf("import cycle %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	exprList, err := ParseAll(TokenizeFile(path, string(b)))
+	if err != nil {
+		return nil, err
+	}
+
+	// 1. evaluate in a fresh environment on top of global frame, functions of the module capture it and see each other
+	stack := r.Stack
+	r.Stack = []*Env{r.Stack[0], {Frame: make(Frame)}}
+	file := &importFile{path: path, imported: make(Frame)}
+	r.importing = append(r.importing, file)
+	exports, err := func() (Frame, error) {
+		for _, expr := range exprList {
+			if _, err := r.Step(ctx, expr); err != nil {
+				return nil, err
+			}
+		}
+		// 2. export the bindings of the module, not those of its imports
+		exports := make(Frame)
+		for name, o := range r.Stack[len(r.Stack)-1].Frame {
+			if imported, ok := file.imported[name]; ok && Equal(imported, o) {
+				continue
+			}
+			exports[name] = o
+		}
+		return exports, nil
+	}()
+	r.importing = r.importing[:len(r.importing)-1]
+	stack[0] = r.Stack[0] // global frame might have been copied on write
+	r.Stack = stack
+	if err != nil {
+		return nil, err
+	}
+	// 3. cache
+	return r.imported.put(path, exports), nil
+}
+
+var importModule = Module{
+	Name: "import",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) < 1 || len(expr.Args) > 2 {
+			return nil, newError(ErrorArity, "import requires 1 or 2 arguments")
+		}
+		v, err := r.Step(ctx, expr.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		path, ok := v.(String)
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "first argument must be string")
+		}
+		var prefix String
+		if len(expr.Args) == 2 {
+			alias, ok := expr.Args[1].(NameExpr)
+			if !ok {
+				return nil, newError(ErrorTypeMismatch, "second argument must be a name")
+			}
+			prefix = String(alias.Name) + "."
+		}
+		exports, err := r.Import(ctx, expr.Span.File, string(path))
+		if err != nil {
+			return nil, err
+		}
+		frame := r.writableFrame(len(r.Stack) - 1)
+		if prefix == "" {
+			for name, o := range exports {
+				if bound, ok := frame[name]; ok && !Equal(bound, o) {
+					return nil, newError(ErrorRuntime, "import %s: %s is already bound, import with an alias", path, name)
+				}
+			}
+		}
+		// names bound at top level of a file being imported are not exported by that file
+		var file *importFile
+		if len(r.importing) > 0 && len(r.Stack) == 2 {
+			file = r.importing[len(r.importing)-1]
+		}
+		var output Dict
+		for name, o := range exports {
+			frame[prefix+name] = o
+			if file != nil {
+				file.imported[prefix+name] = o
+			}
+			output.Set(prefix+name, o)
+		}
+		return output, nil
+	},
+	Man: "module: (import \"lib.lisp\" lib) - evaluate a file once and bind the variables it defines at top level, prefixed by lib. if alias is given, it is an error if a name is already bound without alias",
+}
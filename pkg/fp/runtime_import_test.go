@@ -0,0 +1,54 @@
+package fp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles : create files in a temporary directory, return the directory
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestImport(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"base.lisp":   `(let double (lambda x (mul x 2)))`,
+		"lib.lisp":    `(import "base.lisp") (let quad (lambda x (double (double x))))`,
+		"cycle.lisp":  `(import "cycle2.lisp")`,
+		"cycle2.lisp": `(import "cycle.lisp")`,
+		"clash.lisp":  `(let quad 0)`,
+	})
+	r := NewStdRuntime()
+	r.SearchPath = []string{dir}
+
+	exports := mustEval(t, r, `(import "lib.lisp")`).(Dict)
+	if _, ok := exports.Get(String("double")); ok || exports.Len() != 1 {
+		t.Errorf("lib.lisp must export only quad but exported %v", exports)
+	}
+	expect(t, r, `(quad 3)`, Int(12))
+	expectError(t, r, `double`, ErrorUnboundName)
+
+	// importing the same file again binds the same values
+	expect(t, r, `(len (import "lib.lisp"))`, Int(1))
+	expect(t, r, `(import "lib.lisp" lib) (lib.quad 1)`, Int(4))
+
+	for _, code := range []string{
+		`(import "clash.lisp")`,
+		`(import "cycle.lisp")`,
+		`(import "missing.lisp")`,
+		`(import 1)`,
+	} {
+		if _, err := eval(r, code); err == nil {
+			t.Errorf("%s: expected an error", code)
+		}
+	}
+	expect(t, r, `(import "clash.lisp" c) c.quad`, Int(0))
+}
@@ -0,0 +1,141 @@
+package fp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// makeWriteModule : module writing its arguments to the writer chosen by the runtime
+func makeWriteModule(name String, writer func(r *Runtime) io.Writer, format func(values []Object) string, man string) Module {
+	return Module{
+		Name: name,
+		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+			values, err := r.stepArgs(ctx, expr)
+			if err != nil {
+				return nil, err
+			}
+			w := writer(r)
+			if w == nil {
+				return nil, newError(ErrorRuntime, "%s has no output", name)
+			}
+			if _, err := io.WriteString(w, format(values)); err != nil {
+				return nil, err
+			}
+			return Int(len(values)), nil
+		},
+		Man: man,
+	}
+}
+
+func stdout(r *Runtime) io.Writer {
+	return r.Stdout
+}
+
+func stderr(r *Runtime) io.Writer {
+	return r.Stderr
+}
+
+// joinLine : values separated by spaces, followed by a newline
+func joinLine(values []Object) string {
+	var parts []string
+	for _, v := range values {
+		parts = append(parts, fmt.Sprintf("%v", v))
+	}
+	return strings.Join(parts, " ") + "\n"
+}
+
+var printModule = makeWriteModule("print", stdout, func(values []Object) string {
+	s := ""
+	for _, v := range values {
+		s += fmt.Sprintf("%v ", v)
+	}
+	return s + "\n"
+}, "module: (print 1 x (lambda 3)) - print values")
+
+var printlnModule = makeWriteModule("println", stdout, joinLine, "module: (println \"x =\" x) - print values separated by spaces and a newline")
+
+var eprintModule = makeWriteModule("eprint", stderr, joinLine, "module: (eprint \"error:\" x) - println to standard error")
+
+// sharedInput : buffered reader of standard input shared by a Runtime and its forks, safe for concurrent reads
+type sharedInput struct {
+	mu     sync.Mutex
+	reader *bufio.Reader
+}
+
+func newSharedInput(rd io.Reader) *sharedInput {
+	return &sharedInput{reader: bufio.NewReader(rd)}
+}
+
+func (in *sharedInput) Read(p []byte) (int, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.reader.Read(p)
+}
+
+func (in *sharedInput) readLine() (string, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return in.reader.ReadString('\n')
+}
+
+func (in *sharedInput) readAll() ([]byte, error) {
+	in.mu.Lock()
+	defer in.mu.Unlock()
+	return io.ReadAll(in.reader)
+}
+
+// input : Stdin, wrapped in a sharedInput if it was replaced after NewRuntime, forks made afterward share it
+func (r *Runtime) input() (*sharedInput, error) {
+	if r.Stdin == nil {
+		return nil, newError(ErrorRuntime, "no input")
+	}
+	in, ok := r.Stdin.(*sharedInput)
+	if !ok {
+		in = newSharedInput(r.Stdin)
+		r.Stdin = in
+	}
+	return in, nil
+}
+
+var readLineModule = Module{
+	Name: "read-line",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) != 0 {
+			return nil, newError(ErrorArity, "read-line requires no argument")
+		}
+		in, err := r.input()
+		if err != nil {
+			return nil, err
+		}
+		line, err := in.readLine()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		return String(line), nil
+	},
+	Man: "module: (read-line) - read a line from standard input including the newline, \"\" at the end of input",
+}
+
+var readAllModule = Module{
+	Name: "read-all",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) != 0 {
+			return nil, newError(ErrorArity, "read-all requires no argument")
+		}
+		in, err := r.input()
+		if err != nil {
+			return nil, err
+		}
+		b, err := in.readAll()
+		if err != nil {
+			return nil, err
+		}
+		return String(b), nil
+	},
+	Man: "module: (read-all) - read standard input until the end",
+}
@@ -0,0 +1,49 @@
+package fp
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestStreams(t *testing.T) {
+	out, errOut := &strings.Builder{}, &strings.Builder{}
+	r, err := NewRuntime(WithStdout(out), WithStderr(errOut), WithStdin(strings.NewReader("a\nb\nc")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, r, `(read-line)`, String("a\n"))
+	expect(t, r, `(println "x" (read-line))`, Int(2))
+	expect(t, r, `(eprint (read-all))`, Int(1))
+	expect(t, r, `(read-line)`, String(""))
+	if out.String() != "x b\n\n" || errOut.String() != "c\n" {
+		t.Errorf("println wrote %q, eprint wrote %q", out.String(), errOut.String())
+	}
+}
+
+// TestStdinShared : forks read lines from the same buffer, every line is read exactly once, run with -race
+func TestStdinShared(t *testing.T) {
+	var lines []string
+	for i := 0; i < 1000; i++ {
+		lines = append(lines, fmt.Sprintf("line %03d", i))
+	}
+	input := strings.Join(lines, "\n") + "\n"
+	shared, err := NewRuntime(WithStdin(strings.NewReader(input)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replaced := NewStdRuntime()
+	replaced.Stdin = strings.NewReader(input) // replacing Stdin after NewRuntime also shares it with forks
+	for _, r := range []*Runtime{shared, replaced} {
+		v := mustEval(t, r, `(pmap (range 1 1000) (lambda x (trim (read-line))) 8)`)
+		var got []string
+		for _, o := range v.(List) {
+			got = append(got, string(o.(String)))
+		}
+		sort.Strings(got)
+		if strings.Join(got, "\n") != strings.Join(lines, "\n") {
+			t.Errorf("lines read by forks differ from input")
+		}
+	}
+}
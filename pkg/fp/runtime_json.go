@@ -0,0 +1,265 @@
+package fp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// FromGo : convert a Go value to an Object
+//
+// nil is nil, booleans are Bool, integers are Int (BigInt if they overflow), floats are Float, strings are String,
+// slices and arrays are List, maps are Dict, structs are Dict of their exported fields named by their json tag,
+// pointers are dereferenced, Objects are unchanged and other values are converted to their String representation
+func FromGo(v any) Object {
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case Object:
+		return v
+	case json.Number:
+		return numberFromJSON(v)
+	case *big.Int:
+		if v == nil {
+			return nil
+		}
+		return normalizeBig(new(big.Int).Set(v))
+	case *regexp.Regexp:
+		return Regex{Value: v}
+	}
+	return fromReflect(reflect.ValueOf(v))
+}
+
+func fromReflect(v reflect.Value) Object {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return nil
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+		return FromGo(v.Elem().Interface())
+	case reflect.Bool:
+		return Bool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Int(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return normalizeBig(new(big.Int).SetUint64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return Float(v.Float())
+	case reflect.String:
+		return String(v.String())
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return List(nil)
+		}
+		l := make(List, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			l = append(l, FromGo(v.Index(i).Interface()))
+		}
+		return l
+	case reflect.Map:
+		var d Dict
+		iter := v.MapRange()
+		for iter.Next() {
+			d.Set(FromGo(iter.Key().Interface()), FromGo(iter.Value().Interface()))
+		}
+		return d
+	case reflect.Struct:
+		var d Dict
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, ok := fieldName(field)
+			if !ok {
+				continue
+			}
+			d.Set(String(name), FromGo(v.Field(i).Interface()))
+		}
+		return d
+	default:
+		return String(fmt.Sprintf("%v", v.Interface()))
+	}
+}
+
+// fieldName : name of a struct field in a Dict, the name in its json tag if any, false if the field is skipped
+func fieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return "", false
+	case "":
+		return field.Name, true
+	default:
+		return name, true
+	}
+}
+
+// ToGo : convert an Object to a Go value
+//
+// nil is nil, Bool is bool, Int is int, BigInt is *big.Int, Float is float64, String is string, List is []any,
+// Dict is map[string]any with keys converted by their String representation, Regex is *regexp.Regexp
+// and other Objects are unchanged
+func ToGo(o Object) any {
+	switch o := o.(type) {
+	case nil:
+		return nil
+	case Bool:
+		return bool(o)
+	case Int:
+		return int(o)
+	case BigInt:
+		return new(big.Int).Set(o.Value)
+	case Float:
+		return float64(o)
+	case String:
+		return string(o)
+	case List:
+		l := make([]any, 0, len(o))
+		for _, elem := range o {
+			l = append(l, ToGo(elem))
+		}
+		return l
+	case Dict:
+		m := make(map[string]any, o.Len())
+		for _, entry := range o.Entries() {
+			m[entry.Key.String()] = ToGo(entry.Value)
+		}
+		return m
+	case Regex:
+		return o.Value
+	default:
+		return o
+	}
+}
+
+// numberFromJSON : Int if the number is an integer, Float otherwise
+func numberFromJSON(n json.Number) Object {
+	if v, ok := new(big.Int).SetString(n.String(), 10); ok {
+		return normalizeBig(v)
+	}
+	f, _ := strconv.ParseFloat(n.String(), 64)
+	return Float(f)
+}
+
+// toJSON : Go value encoding o as JSON, error if o has no JSON representation
+func toJSON(o Object) (any, error) {
+	switch o := o.(type) {
+	case nil, Bool, Int, String:
+		return ToGo(o), nil
+	case BigInt:
+		return o.Value, nil
+	case Float:
+		if math.IsNaN(float64(o)) || math.IsInf(float64(o), 0) {
+			return nil, newError(ErrorTypeMismatch, "json-stringify cannot encode %s", o)
+		}
+		return json.Number(o.String()), nil // 3.0 stays a Float when parsed back
+	case List:
+		l := make([]any, 0, len(o))
+		for _, elem := range o {
+			v, err := toJSON(elem)
+			if err != nil {
+				return nil, err
+			}
+			l = append(l, v)
+		}
+		return l, nil
+	case Dict:
+		m := make(map[string]any, o.Len())
+		for _, entry := range o.Entries() {
+			switch entry.Key.(type) {
+			case String, Int, BigInt, Float, Bool:
+			default:
+				return nil, newError(ErrorTypeMismatch, "json-stringify cannot encode key of type %s", getType(entry.Key))
+			}
+			v, err := toJSON(entry.Value)
+			if err != nil {
+				return nil, err
+			}
+			key := entry.Key.String()
+			if _, ok := m[key]; ok {
+				return nil, newError(ErrorRuntime, "json-stringify duplicate key %q, e.g. 1 and \"1\"", key)
+			}
+			m[key] = v
+		}
+		return m, nil
+	default:
+		return nil, newError(ErrorTypeMismatch, "json-stringify cannot encode %s", getType(o))
+	}
+}
+
+var jsonParseExtension = Extension{
+	Name: "json-parse",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "json-parse requires 1 argument")
+		}
+		s, err := stringArg("json-parse", values[0])
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(strings.NewReader(string(s)))
+		decoder.UseNumber()
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			return nil, newError(ErrorRuntime, "json-parse: %v", err)
+		}
+		if decoder.More() {
+			return nil, newError(ErrorRuntime, "json-parse: unexpected data after top-level value")
+		}
+		return FromGo(v), nil
+	},
+	Man: "module: (json-parse \"{\\\"a\\\": [1, 2.5]}\") - objects are Dict, arrays are List, numbers are Int or Float, null is nil",
+}
+
+// maxIndent : longest indent of json-stringify, as in JSON.stringify of javascript
+const maxIndent = 10
+
+var jsonStringifyExtension = Extension{
+	Name: "json-stringify",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, newError(ErrorArity, "json-stringify requires 1 or 2 arguments")
+		}
+		v, err := toJSON(values[0])
+		if err != nil {
+			return nil, err
+		}
+		indent := ""
+		if len(values) == 2 {
+			switch i := values[1].(type) {
+			case Int:
+				if i < 0 || i > maxIndent {
+					return nil, newError(ErrorRuntime, "json-stringify indent must be between 0 and %d", maxIndent)
+				}
+				indent = strings.Repeat(" ", int(i))
+			case String:
+				if len(i) > maxIndent {
+					return nil, newError(ErrorRuntime, "json-stringify indent must be at most %d bytes", maxIndent)
+				}
+				indent = string(i)
+			default:
+				return nil, newError(ErrorTypeMismatch, "json-stringify indent must be Int or String")
+			}
+		}
+		buf := &bytes.Buffer{}
+		encoder := json.NewEncoder(buf)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", indent)
+		if err := encoder.Encode(v); err != nil {
+			return nil, newError(ErrorRuntime, "json-stringify: %v", err)
+		}
+		return String(strings.TrimSuffix(buf.String(), "\n")), nil
+	},
+	Man: "module: (json-stringify d 2) - encode as JSON, keys are sorted, pretty-printed with 2 spaces (or a given String) of indent, at most 10",
+}
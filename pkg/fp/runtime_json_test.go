@@ -0,0 +1,76 @@
+package fp
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func TestJSON(t *testing.T) {
+	type payload struct {
+		Name  string         `json:"name"`
+		Tags  []string       `json:"tags"`
+		Score float64        `json:"score"`
+		Extra map[string]int `json:"extra"`
+		Skip  int            `json:"-"`
+		skip  int
+	}
+	r := NewStdRuntime()
+	r.SetGlobal("p", FromGo(payload{Name: "é", Tags: []string{"a"}, Score: 2, Extra: map[string]int{"n": 1}}))
+	for _, c := range []struct {
+		code string
+		want Object
+	}{
+		{`(json-stringify p)`, String(`{"extra":{"n":1},"name":"é","score":2.0,"tags":["a"]}`)},
+		{`(eq (json-parse (json-stringify p)) p)`, Bool(true)},
+		{`(json-stringify (list 1 "<a>"))`, String(`[1,"<a>"]`)},
+		{`(json-stringify (list 1) 1)`, String("[\n 1\n]")},
+		{`(json-stringify (list 1) "\t")`, String("[\n\t1\n]")},
+		{`(json-stringify (dict 1 2))`, String(`{"1":2}`)},
+		{`(json-parse "123456789012345678901234567890")`, mustEval(t, r, `(mul 123456789012345678901234567890 1)`)},
+		{`(type (json-parse "3.0"))`, String("Float")},
+		{`(json-parse " [] ")`, List{}},
+	} {
+		expect(t, r, c.code, c.want)
+	}
+
+	for _, c := range []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(json-stringify (dict 1 "a" "1" "b"))`, ErrorRuntime},
+		{`(json-stringify (list 1) 1000000000000)`, ErrorRuntime},
+		{`(json-stringify (list 1) -1)`, ErrorRuntime},
+		{`(json-stringify (list 1) "           ")`, ErrorRuntime},
+		{`(json-stringify (list 1) 1.5)`, ErrorTypeMismatch},
+		{`(json-stringify (exp 1000.0))`, ErrorTypeMismatch},
+		{`(json-stringify (lambda x x))`, ErrorTypeMismatch},
+		{`(json-stringify (dict (list 1) 1))`, ErrorTypeMismatch},
+		{`(json-parse "{")`, ErrorRuntime},
+		{`(json-parse "1 2")`, ErrorRuntime},
+	} {
+		expectError(t, r, c.code, c.kind)
+	}
+}
+
+func TestGoConversion(t *testing.T) {
+	v := mustEval(t, NewStdRuntime(), `(json-parse "{\"a\": [1, 2.5, null, true]}")`)
+	want := map[string]any{"a": []any{1, 2.5, nil, true}}
+	if got := ToGo(v); !reflect.DeepEqual(got, want) {
+		t.Errorf("ToGo: got %#v want %#v", got, want)
+	}
+	for _, c := range []struct {
+		in   any
+		want Object
+	}{
+		{nil, nil},
+		{uint64(1) << 63, BigInt{Value: new(big.Int).Lsh(big.NewInt(1), 63)}},
+		{[]int(nil), List(nil)},
+		{&struct{ X *int }{}, func() Object { var d Dict; d.Set(String("X"), nil); return d }()},
+		{[2]bool{true, false}, List{Bool(true), Bool(false)}},
+	} {
+		if got := FromGo(c.in); !Equal(got, c.want) {
+			t.Errorf("FromGo(%#v): got %v want %v", c.in, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// Limits : resource limits of an evaluation, zero means unlimited
+//
+// an evaluation is a call to Step from outside of any other Step, forks made during an evaluation (e.g. by pmap)
+// count toward it. sizes are checked on the values returned by modules and extensions
+type Limits struct {
+	MaxStackDepth  int   `json:"max_stack_depth,omitempty"` // number of nested lambda calls and of nested evaluations in modules
+	MaxSteps       int64 `json:"max_steps,omitempty"`       // number of evaluated expressions
+	MaxListLength  int   `json:"max_list_length,omitempty"` // number of elements of a List or entries of a Dict
+	MaxStringSize  int   `json:"max_string_size,omitempty"` // number of bytes of a String
+	MaxAllocations int64 `json:"max_allocations,omitempty"` // number of objects created, a List or a Dict counts its elements, an Int its 64-bit words
+	MaxIntBits     int   `json:"max_int_bits,omitempty"`    // number of bits of an Int, checked on arithmetic results and before pow
+}
+
+// DefaultLimits : limits of a new Runtime
+var DefaultLimits = Limits{
+	MaxStackDepth: MAX_STACK_DEPTH,
+}
+
+var StepLimitError = errors.New("step limit exceeded")
+var ListLengthLimitError = errors.New("list length limit exceeded")
+var StringSizeLimitError = errors.New("string size limit exceeded")
+var AllocationLimitError = errors.New("allocation limit exceeded")
+var IntSizeLimitError = errors.New("integer size limit exceeded")
+
+// usage : resources used by the current evaluation, shared with forks made during the evaluation
+type usage struct {
+	steps       atomic.Int64
+	allocations atomic.Int64
+}
+
+type limitsKey struct{}
+
+// LimitsFromContext : limits of the evaluation calling an extension, for extensions that build large values
+func LimitsFromContext(ctx context.Context) Limits {
+	limits, _ := ctx.Value(limitsKey{}).(Limits)
+	return limits
+}
+
+func withLimits(ctx context.Context, limits Limits) context.Context {
+	return context.WithValue(ctx, limitsKey{}, limits)
+}
+
+// checkLength : error if a List or a Dict of length n is not allowed
+func (l Limits) checkLength(n int) error {
+	if l.MaxListLength > 0 && n > l.MaxListLength {
+		return fmt.Errorf("%w: length %d, limit %d", ListLengthLimitError, n, l.MaxListLength)
+	}
+	return nil
+}
+
+// checkSize : error if a String of n bytes is not allowed
+func (l Limits) checkSize(n int) error {
+	if l.MaxStringSize > 0 && n > l.MaxStringSize {
+		return fmt.Errorf("%w: size %d, limit %d", StringSizeLimitError, n, l.MaxStringSize)
+	}
+	return nil
+}
+
+// checkBits : error if an Int of n bits is not allowed
+func (l Limits) checkBits(n int) error {
+	if l.MaxIntBits > 0 && n > l.MaxIntBits {
+		return fmt.Errorf("%w: %d bits, limit %d", IntSizeLimitError, n, l.MaxIntBits)
+	}
+	return nil
+}
+
+// count : count a step of evaluation and check the stack depth
+//
+// the depth is the number of environments on stack and the number of nested Step calls, the latter bounds the go stack
+// when modules evaluate their arguments, call functions or expand macros without pushing an environment
+func (r *Runtime) count() error {
+	if r.Limits.MaxStackDepth > 0 && max(len(r.Stack), r.depth) > r.Limits.MaxStackDepth {
+		return StackOverflowError
+	}
+	steps := r.usage.steps.Add(1)
+	if r.Limits.MaxSteps > 0 && steps > r.Limits.MaxSteps {
+		return fmt.Errorf("%w: limit %d", StepLimitError, r.Limits.MaxSteps)
+	}
+	return nil
+}
+
+// allocate : count n objects created
+func (r *Runtime) allocate(n int64) error {
+	allocations := r.usage.allocations.Add(n)
+	if r.Limits.MaxAllocations > 0 && allocations > r.Limits.MaxAllocations {
+		return fmt.Errorf("%w: limit %d", AllocationLimitError, r.Limits.MaxAllocations)
+	}
+	return nil
+}
+
+// account : check the size of a value returned by a module and count its allocations
+func (r *Runtime) account(v Object) error {
+	n := int64(1)
+	switch v := v.(type) {
+	case List:
+		if err := r.Limits.checkLength(len(v)); err != nil {
+			return err
+		}
+		n += int64(len(v))
+	case Dict:
+		if err := r.Limits.checkLength(v.Len()); err != nil {
+			return err
+		}
+		n += int64(v.Len())
+	case String:
+		if err := r.Limits.checkSize(len(v)); err != nil {
+			return err
+		}
+	case BigInt:
+		if err := r.Limits.checkBits(v.Value.BitLen()); err != nil {
+			return err
+		}
+		n += int64(len(v.Value.Bits()))
+	}
+	return r.allocate(n)
+}
@@ -0,0 +1,81 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// nested : n nested calls of add around 0
+func nested(n int) string {
+	return strings.Repeat("(add 1 ", n) + "0" + strings.Repeat(")", n)
+}
+
+func TestLimits(t *testing.T) {
+	for _, c := range []struct {
+		name   string
+		limits Limits
+		code   string
+		want   error
+	}{
+		{"steps", Limits{MaxSteps: 10000}, `(let f (lambda n (f n))) (f 1)`, StepLimitError},
+		{"stack", Limits{MaxStackDepth: 100}, `(let g (lambda n (add 1 (g n)))) (g 1)`, StackOverflowError},
+		{"nested evaluations", Limits{MaxStackDepth: 100}, nested(1000), StackOverflowError},
+		{"nested calls in modules", Limits{MaxStackDepth: 100}, `(let h (lambda n (peek (map (list n) h) 1))) (h 1)`, StackOverflowError},
+		{"list", Limits{MaxListLength: 100}, `(range 1 1000)`, ListLengthLimitError},
+		{"string", Limits{MaxStringSize: 10}, `"this string is too long"`, StringSizeLimitError},
+		{"format width", Limits{MaxStringSize: 1000}, `(format "%9999d" 1)`, StringSizeLimitError},
+		{"format widths", Limits{MaxStringSize: 1000}, `(format "%600d%600d" 1 2)`, StringSizeLimitError},
+		{"allocations", Limits{MaxAllocations: 1000}, `(let h (lambda n (case n 0 0 _ (tail (range 1 50) (h (sub n 1)))))) (h 100)`, AllocationLimitError},
+		{"int bits of pow", Limits{MaxIntBits: 1000}, `(pow 2 2000)`, IntSizeLimitError},
+		{"int bits of repeated squaring", Limits{MaxIntBits: 10000}, `(let sq (lambda x n (case n 0 x _ (sq (mul x x) (sub n 1))))) (sq 3 100)`, IntSizeLimitError},
+		{"int bits in calls from modules", Limits{MaxIntBits: 100}, `(foldl (range 1 100) mul 1)`, IntSizeLimitError},
+		{"int words are allocations", Limits{MaxAllocations: 100}, `(pow 2 10000)`, AllocationLimitError},
+	} {
+		t.Run(c.name, func(t *testing.T) {
+			r := NewStdRuntime()
+			r.Limits = c.limits
+			if _, err := eval(r, c.code); !errors.Is(err, c.want) {
+				t.Errorf("%s: got %v want %v", c.code, err, c.want)
+			}
+		})
+	}
+}
+
+// TestLimitsDepth : the default limits stop deep go recursion with an error instead of a go stack overflow
+func TestLimitsDepth(t *testing.T) {
+	r := NewStdRuntime()
+	expectError(t, r, nested(100000), ErrorStackOverflow)
+	expect(t, r, nested(100), Int(100))
+	if r.depth != 0 {
+		t.Errorf("depth %d after evaluation", r.depth)
+	}
+}
+
+// TestLimitsReset : counters are reset between evaluations
+func TestLimitsReset(t *testing.T) {
+	r := NewStdRuntime()
+	r.Limits = Limits{MaxAllocations: 100}
+	for i := 0; i < 10; i++ {
+		expect(t, r, `(len (range 1 50))`, Int(50))
+	}
+}
+
+// TestNoPanics : wrong arguments to builtins are errors, a panic in an extension does not crash the program
+func TestNoPanics(t *testing.T) {
+	for _, code := range []string{`(sign)`, `(append)`, `(boom 1)`} {
+		r := NewStdRuntime()
+		r.LoadExtension(Extension{
+			Name: "boom",
+			Exec: func(ctx context.Context, values ...Object) (Object, error) {
+				return values[1], nil
+			},
+		})
+		_, err := eval(r, code)
+		var evalErr *EvalError
+		if !errors.As(err, &evalErr) {
+			t.Errorf("%s: got %v want an EvalError", code, err)
+		}
+	}
+}
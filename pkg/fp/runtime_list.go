@@ -0,0 +1,443 @@
+package fp
+
+import (
+	"context"
+	"slices"
+	"strings"
+)
+
+// caller : apply a function value (Lambda or Module) to evaluated arguments
+type caller func(f Object, args ...Object) (Object, error)
+
+// makeHigherOrderModule : like an Extension but exec can call functions passed as arguments
+func makeHigherOrderModule(name String, exec func(ctx context.Context, call caller, values ...Object) (Object, error), man string) Module {
+	return Module{
+		Name: name,
+		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+			args, err := r.stepArgs(ctx, expr)
+			if err != nil {
+				return nil, err
+			}
+			return exec(ctx, func(f Object, args ...Object) (Object, error) {
+				return r.call(ctx, expr, f, args...)
+			}, args...)
+		},
+		Man: man,
+	}
+}
+
+func listArg(name String, o Object) (List, error) {
+	l, ok := o.(List)
+	if !ok && o != nil { // empty range returns nil
+		return nil, newError(ErrorTypeMismatch, "%s requires a list but got %s", name, getType(o))
+	}
+	return l, nil
+}
+
+func intArg(name String, o Object) (Int, error) {
+	i, ok := o.(Int)
+	if !ok {
+		return 0, newError(ErrorTypeMismatch, "%s requires an integer but got %s", name, getType(o))
+	}
+	return i, nil
+}
+
+// callBool : call a predicate
+func callBool(name String, call caller, f Object, args ...Object) (Bool, error) {
+	v, err := call(f, args...)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(Bool)
+	if !ok {
+		return false, newError(ErrorTypeMismatch, "%s requires a predicate returning Bool but got %s", name, getType(v))
+	}
+	return b, nil
+}
+
+var mapModule = makeHigherOrderModule("map", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 {
+		return nil, newError(ErrorArity, "map requires 2 arguments")
+	}
+	l, err := listArg("map", values[0])
+	if err != nil {
+		return nil, err
+	}
+	var outputs List
+	for _, v := range l {
+		o, err := call(values[1], v)
+		if err != nil {
+			return nil, err
+		}
+		outputs = append(outputs, o)
+	}
+	return outputs, nil
+}, "module: (map l (lambda y (add 1 y))) - map or for loop")
+
+var filterModule = makeHigherOrderModule("filter", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 {
+		return nil, newError(ErrorArity, "filter requires 2 arguments")
+	}
+	l, err := listArg("filter", values[0])
+	if err != nil {
+		return nil, err
+	}
+	var outputs List
+	for _, v := range l {
+		keep, err := callBool("filter", call, values[1], v)
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			outputs = append(outputs, v)
+		}
+	}
+	return outputs, nil
+}, "module: (filter l (lambda x (gt x 0))) - elements of l that satisfy the predicate")
+
+// foldl : f(...f(f(init, l1), l2)..., ln)
+func foldl(call caller, f Object, init Object, l List) (Object, error) {
+	acc := init
+	for _, v := range l {
+		var err error
+		acc, err = call(f, acc, v)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+var foldlModule = makeHigherOrderModule("foldl", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 3 {
+		return nil, newError(ErrorArity, "foldl requires 3 arguments")
+	}
+	l, err := listArg("foldl", values[0])
+	if err != nil {
+		return nil, err
+	}
+	return foldl(call, values[1], values[2], l)
+}, "module: (foldl l (lambda acc x (sub acc x)) 0) - fold from the left, ((0 - l1) - l2) - ...")
+
+var foldrModule = makeHigherOrderModule("foldr", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 3 {
+		return nil, newError(ErrorArity, "foldr requires 3 arguments")
+	}
+	l, err := listArg("foldr", values[0])
+	if err != nil {
+		return nil, err
+	}
+	acc := values[2]
+	for i := len(l) - 1; i >= 0; i-- {
+		acc, err = call(values[1], l[i], acc)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}, "module: (foldr l (lambda x acc (sub x acc)) 0) - fold from the right, l1 - (l2 - (... - 0))")
+
+var reduceModule = makeHigherOrderModule("reduce", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 && len(values) != 3 {
+		return nil, newError(ErrorArity, "reduce requires 2 or 3 arguments")
+	}
+	l, err := listArg("reduce", values[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 3 {
+		return foldl(call, values[1], values[2], l)
+	}
+	if len(l) == 0 {
+		return nil, newError(ErrorRuntime, "reduce of empty list without initial value")
+	}
+	return foldl(call, values[1], l[0], l[1:])
+}, "module: (reduce l add 0) - foldl, the first element is the initial value if it is omitted")
+
+var anyModule = makeHigherOrderModule("any", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 {
+		return nil, newError(ErrorArity, "any requires 2 arguments")
+	}
+	l, err := listArg("any", values[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range l {
+		ok, err := callBool("any", call, values[1], v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return Bool(true), nil
+		}
+	}
+	return Bool(false), nil
+}, "module: (any l (lambda x (gt x 0))) - true if at least one element satisfies the predicate")
+
+var allModule = makeHigherOrderModule("all", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 {
+		return nil, newError(ErrorArity, "all requires 2 arguments")
+	}
+	l, err := listArg("all", values[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range l {
+		ok, err := callBool("all", call, values[1], v)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return Bool(false), nil
+		}
+	}
+	return Bool(true), nil
+}, "module: (all l (lambda x (gt x 0))) - true if every element satisfies the predicate")
+
+var findModule = makeHigherOrderModule("find", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 && len(values) != 3 {
+		return nil, newError(ErrorArity, "find requires 2 or 3 arguments")
+	}
+	l, err := listArg("find", values[0])
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range l {
+		ok, err := callBool("find", call, values[1], v)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return v, nil
+		}
+	}
+	if len(values) == 3 {
+		return values[2], nil
+	}
+	return nil, newError(ErrorRuntime, "no element satisfies the predicate")
+}, "module: (find l (lambda x (gt x 0)) 0) - first element that satisfies the predicate, return default 0 if not found")
+
+var sortModule = makeHigherOrderModule("sort", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 1 && len(values) != 2 {
+		return nil, newError(ErrorArity, "sort requires 1 or 2 arguments")
+	}
+	l, err := listArg("sort", values[0])
+	if err != nil {
+		return nil, err
+	}
+	l = slices.Clone(l)
+	var sortErr error
+	slices.SortStableFunc(l, func(a Object, b Object) int {
+		if sortErr != nil {
+			return 0
+		}
+		if len(values) == 1 {
+			c, ok := compare(a, b)
+			if !ok {
+				sortErr = newError(ErrorTypeMismatch, "sort cannot compare %s and %s", getType(a), getType(b))
+			}
+			return c
+		}
+		less, err := callBool("sort", call, values[1], a, b)
+		if err != nil {
+			sortErr = err
+			return 0
+		}
+		if less {
+			return -1
+		}
+		return +1 // stable sort only asks whether b must go before a
+	})
+	if sortErr != nil {
+		return nil, sortErr
+	}
+	return l, nil
+}, "module: (sort l (lambda a b (gt a b))) - stable sort, ascending if the comparator is omitted")
+
+var groupByModule = makeHigherOrderModule("group-by", func(ctx context.Context, call caller, values ...Object) (Object, error) {
+	if len(values) != 2 {
+		return nil, newError(ErrorArity, "group-by requires 2 arguments")
+	}
+	l, err := listArg("group-by", values[0])
+	if err != nil {
+		return nil, err
+	}
+	// groups are appended in place and converted to a Dict once
+	var index Dict // key -> position of its group
+	var keys []Object
+	var groups []List
+	for _, v := range l {
+		key, err := call(values[1], v)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index.Get(key)
+		if !ok {
+			i = Int(len(groups))
+			index.Set(key, i)
+			keys = append(keys, key)
+			groups = append(groups, nil)
+		}
+		groups[i.(Int)] = append(groups[i.(Int)], v)
+	}
+	var output Dict
+	for i, key := range keys {
+		output.Set(key, groups[i])
+	}
+	return output, nil
+}, "module: (group-by l (lambda x (mod x 2))) - dict from key to the list of elements with that key")
+
+var zipExtension = Extension{
+	Name: "zip",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		var lists []List
+		for _, v := range values {
+			l, err := listArg("zip", v)
+			if err != nil {
+				return nil, err
+			}
+			lists = append(lists, l)
+		}
+		var outputs List
+		for i := 0; len(lists) > 0; i++ {
+			var tuple List
+			for _, l := range lists {
+				if i >= len(l) {
+					return outputs, nil
+				}
+				tuple = append(tuple, l[i])
+			}
+			outputs = append(outputs, tuple)
+		}
+		return outputs, nil
+	},
+	Man: "module: (zip l1 l2) - list of [l1[i], l2[i]], stop at the shortest list",
+}
+
+var enumerateExtension = Extension{
+	Name: "enumerate",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "enumerate requires 1 argument")
+		}
+		l, err := listArg("enumerate", values[0])
+		if err != nil {
+			return nil, err
+		}
+		var outputs List
+		for i, v := range l {
+			outputs = append(outputs, List{Int(i + 1), v})
+		}
+		return outputs, nil
+	},
+	Man: "module: (enumerate l) - list of [i, l[i]] (list is 1-indexing)",
+}
+
+var reverseExtension = Extension{
+	Name: "reverse",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "reverse requires 1 argument")
+		}
+		l, err := listArg("reverse", values[0])
+		if err != nil {
+			return nil, err
+		}
+		l = slices.Clone(l)
+		slices.Reverse(l)
+		return l, nil
+	},
+	Man: "module: (reverse l) - list in reverse order",
+}
+
+func flatten(l List) List {
+	var outputs List
+	for _, v := range l {
+		if inner, ok := v.(List); ok {
+			outputs = append(outputs, flatten(inner)...)
+		} else {
+			outputs = append(outputs, v)
+		}
+	}
+	return outputs
+}
+
+var flattenExtension = Extension{
+	Name: "flatten",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "flatten requires 1 argument")
+		}
+		l, err := listArg("flatten", values[0])
+		if err != nil {
+			return nil, err
+		}
+		return flatten(l), nil
+	},
+	Man: "module: (flatten (list 1 (list 2 (list 3)))) - list of non-list elements of nested lists, [1, 2, 3]",
+}
+
+var concatExtension = Extension{
+	Name: "concat",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) > 0 {
+			if _, ok := values[0].(String); ok {
+				strs, err := stringArgs("concat", values)
+				if err != nil {
+					return nil, err
+				}
+				return String(strings.Join(strs, "")), nil
+			}
+		}
+		var outputs List
+		for _, v := range values {
+			l, err := listArg("concat", v)
+			if err != nil {
+				return nil, err
+			}
+			outputs = append(outputs, l...)
+		}
+		return outputs, nil
+	},
+	Man: "module: (concat l1 l2) - list of elements of l1 followed by elements of l2, (concat \"ab\" \"cd\") concatenates strings",
+}
+
+var takeExtension = Extension{
+	Name: "take",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 {
+			return nil, newError(ErrorArity, "take requires 2 arguments")
+		}
+		l, err := listArg("take", values[0])
+		if err != nil {
+			return nil, err
+		}
+		n, err := intArg("take", values[1])
+		if err != nil {
+			return nil, err
+		}
+		n = max(0, min(n, Int(len(l))))
+		return slices.Clone(l[:n]), nil
+	},
+	Man: "module: (take l 3) - first 3 elements of l",
+}
+
+var dropExtension = Extension{
+	Name: "drop",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 {
+			return nil, newError(ErrorArity, "drop requires 2 arguments")
+		}
+		l, err := listArg("drop", values[0])
+		if err != nil {
+			return nil, err
+		}
+		n, err := intArg("drop", values[1])
+		if err != nil {
+			return nil, err
+		}
+		n = max(0, min(n, Int(len(l))))
+		return slices.Clone(l[n:]), nil
+	},
+	Man: "module: (drop l 3) - l without its first 3 elements",
+}
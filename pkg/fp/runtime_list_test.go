@@ -0,0 +1,86 @@
+package fp
+
+import (
+	"testing"
+)
+
+// ints : List of Int
+func ints(values ...int) List {
+	l := List{}
+	for _, v := range values {
+		l = append(l, Int(v))
+	}
+	return l
+}
+
+func TestListToolkit(t *testing.T) {
+	evens := Dict{}
+	evens.Set(Int(0), ints(2, 4))
+	evens.Set(Int(1), ints(1, 3, 5))
+	for _, c := range []struct {
+		code string
+		want Object
+	}{
+		{`(map (list 1 2 3) (lambda x (mul x x)))`, ints(1, 4, 9)},
+		{`(filter (range 1 6) (lambda x (eq (mod x 2) 0)))`, ints(2, 4, 6)},
+		{`(range 5 1)`, List{}},
+		{`(len (range 5 1))`, Int(0)},
+		{`(foldl (list 1 2 3) (lambda acc x (sub acc x)) 0)`, Int(-6)},
+		{`(foldr (list 1 2 3) (lambda x acc (sub x acc)) 0)`, Int(2)},
+		{`(reduce (list 1 2 3) add)`, Int(6)},
+		{`(reduce (list) add 7)`, Int(7)},
+		{`(any (list 1 -2) (lambda x (lt x 0)))`, Bool(true)},
+		{`(all (list 1 -2) (lambda x (lt x 0)))`, Bool(false)},
+		{`(all (list) (lambda x false))`, Bool(true)},
+		{`(find (list 1 5 7) (lambda x (gt x 4)) 0)`, Int(5)},
+		{`(find (list 1) (lambda x (gt x 4)) 0)`, Int(0)},
+		{`(sort (list 3 1 2))`, ints(1, 2, 3)},
+		{`(sort (list 3 1 2) (lambda a b (gt a b)))`, ints(3, 2, 1)},
+		{`(map (sort (list (list 2 "a") (list 1 "b") (list 2 "c")) (lambda a b (lt (peek a 1) (peek b 1)))) (lambda p (peek p 2)))`, List{String("b"), String("a"), String("c")}},
+		{`(group-by (range 1 5) (lambda x (mod x 2)))`, evens},
+		{`(zip (list 1 2 3) (list 4 5))`, List{ints(1, 4), ints(2, 5)}},
+		{`(enumerate (list 7 8))`, List{ints(1, 7), ints(2, 8)}},
+		{`(reverse (list 1 2 3))`, ints(3, 2, 1)},
+		{`(flatten (list 1 (list 2 (list 3))))`, ints(1, 2, 3)},
+		{`(concat (list 1) (list 2 3))`, ints(1, 2, 3)},
+		{`(take (list 1 2 3) 2)`, ints(1, 2)},
+		{`(drop (list 1 2 3) 2)`, ints(3)},
+	} {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+func TestListToolkitErrors(t *testing.T) {
+	r := NewStdRuntime()
+	for _, c := range []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(map 1 (lambda x x))`, ErrorTypeMismatch},
+		{`(filter (list 1) 2)`, ErrorTypeMismatch},
+		{`(map (list 1) (lambda x y x))`, ErrorArity},
+		{`(sort (list 1 "a"))`, ErrorTypeMismatch},
+		{`(find (list 1) (lambda x 1) 0)`, ErrorTypeMismatch},
+	} {
+		expectError(t, r, c.code, c.kind)
+	}
+}
+
+// TestGroupByLarge : group-by is linear, 1e5 elements in a few groups
+func TestGroupByLarge(t *testing.T) {
+	r := NewStdRuntime()
+	expect(t, r, `(map (values (group-by (range 1 100000) (lambda x (mod x 3)))) len)`, ints(33333, 33334, 33333))
+}
+
+// TestCallRestoresStack : a callee that empties the stack does not lose the global frame
+func TestCallRestoresStack(t *testing.T) {
+	r := NewStdRuntime()
+	mustEval(t, r, `(let f (lambda x (kaboom)))`)
+	mustEval(t, r, `(map (list 1 2) f)`)
+	mustEval(t, r, `(let g (lambda x (map (list x) (lambda y (kaboom)))))`)
+	mustEval(t, r, `(g 1)`)
+	if len(r.Stack) != 1 || len(r.trace) != 0 {
+		t.Fatalf("stack of %d environments and trace of %d calls after evaluation", len(r.Stack), len(r.trace))
+	}
+	expect(t, r, `(add 1 2)`, Int(3))
+}
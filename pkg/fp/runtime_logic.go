@@ -0,0 +1,204 @@
+package fp
+
+import (
+	"context"
+	"math"
+)
+
+// compare : -1, 0, +1 if a < b, a = b, a > b, ok is false if a and b are not ordered
+//
+// numbers are compared by value, strings lexicographically, lists lexicographically by elements
+func compare(a Object, b Object) (c int, ok bool) {
+	if a, ok := a.(Int); ok {
+		if b, ok := b.(Int); ok {
+			switch {
+			case a < b:
+				return -1, true
+			case a > b:
+				return +1, true
+			default:
+				return 0, true
+			}
+		}
+	}
+	if ba, ok := toBig(a); ok {
+		if bb, ok := toBig(b); ok {
+			return ba.Cmp(bb), true
+		}
+	}
+	if fa, ok := toFloat(a); ok {
+		if fb, ok := toFloat(b); ok {
+			if math.IsNaN(float64(fa)) || math.IsNaN(float64(fb)) {
+				return 0, false
+			}
+			// compare exactly, float64 cannot represent every integer
+			return toBigFloat(a).Cmp(toBigFloat(b)), true
+		}
+	}
+	switch a := a.(type) {
+	case String:
+		b, ok := b.(String)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case a < b:
+			return -1, true
+		case a > b:
+			return +1, true
+		default:
+			return 0, true
+		}
+	case List:
+		b, ok := b.(List)
+		if !ok {
+			return 0, false
+		}
+		for i := 0; i < len(a) && i < len(b); i++ {
+			c, ok := compare(a[i], b[i])
+			if !ok {
+				return 0, false
+			}
+			if c != 0 {
+				return c, true
+			}
+		}
+		return compare(Int(len(a)), Int(len(b)))
+	default:
+		return 0, false
+	}
+}
+
+// makeComparisonExtension : (lt a b c) is a < b < c
+func makeComparisonExtension(name String, holds func(c int) bool, man string) Extension {
+	return Extension{
+		Name: name,
+		Exec: func(ctx context.Context, values ...Object) (Object, error) {
+			if len(values) < 2 {
+				return nil, newError(ErrorArity, "%s requires at least 2 arguments", name)
+			}
+			for i := 0; i+1 < len(values); i++ {
+				c, ok := compare(values[i], values[i+1])
+				if !ok {
+					return nil, newError(ErrorTypeMismatch, "%s cannot compare %s and %s", name, getType(values[i]), getType(values[i+1]))
+				}
+				if !holds(c) {
+					return Bool(false), nil
+				}
+			}
+			return Bool(true), nil
+		},
+		Man: man,
+	}
+}
+
+var ltExtension = makeComparisonExtension("lt", func(c int) bool { return c < 0 }, "module: (lt 1 2 3) - true if each value is less than the next one")
+
+var leExtension = makeComparisonExtension("le", func(c int) bool { return c <= 0 }, "module: (le 1 1 2) - true if each value is less than or equal to the next one")
+
+var gtExtension = makeComparisonExtension("gt", func(c int) bool { return c > 0 }, "module: (gt 3 2 1) - true if each value is greater than the next one")
+
+var geExtension = makeComparisonExtension("ge", func(c int) bool { return c >= 0 }, "module: (ge 2 2 1) - true if each value is greater than or equal to the next one")
+
+var eqExtension = Extension{
+	Name: "eq",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) < 2 {
+			return nil, newError(ErrorArity, "eq requires at least 2 arguments")
+		}
+		for i := 0; i+1 < len(values); i++ {
+			if !Equal(values[i], values[i+1]) {
+				return Bool(false), nil
+			}
+		}
+		return Bool(true), nil
+	},
+	Man: "module: (eq x 1 (sub 2 1)) - true if all values are equal",
+}
+
+var neExtension = Extension{
+	Name: "ne",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 {
+			return nil, newError(ErrorArity, "ne requires 2 arguments")
+		}
+		return Bool(!Equal(values[0], values[1])), nil
+	},
+	Man: "module: (ne x 1) - true if values are not equal",
+}
+
+var notExtension = Extension{
+	Name: "not",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "not requires 1 argument")
+		}
+		b, ok := values[0].(Bool)
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "not requires Bool")
+		}
+		return !b, nil
+	},
+	Man: "module: (not (eq x 1)) - logical negation",
+}
+
+// stepBool : evaluate an expression that must be a Bool
+func (r *Runtime) stepBool(ctx context.Context, name NameExpr, expr Expr) (Bool, error) {
+	v, err := r.Step(ctx, expr)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(Bool)
+	if !ok {
+		return false, newError(ErrorTypeMismatch, "%s requires Bool but got %s", name, getType(v))
+	}
+	return b, nil
+}
+
+var andModule = Module{
+	Name: "and",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		for _, arg := range expr.Args {
+			b, err := r.stepBool(ctx, expr.Name, arg)
+			if err != nil {
+				return nil, err
+			}
+			if !b {
+				return Bool(false), nil
+			}
+		}
+		return Bool(true), nil
+	},
+	Man: "module: (and (gt x 0) (lt x 10)) - logical and, stop at the first false",
+}
+
+var orModule = Module{
+	Name: "or",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		for _, arg := range expr.Args {
+			b, err := r.stepBool(ctx, expr.Name, arg)
+			if err != nil {
+				return nil, err
+			}
+			if b {
+				return Bool(true), nil
+			}
+		}
+		return Bool(false), nil
+	},
+	Man: "module: (or (lt x 0) (gt x 10)) - logical or, stop at the first true",
+}
+
+var ifModule = makeTailModule("if", func(ctx context.Context, r *Runtime, expr LambdaExpr) (Expr, error) {
+	if len(expr.Args) != 3 {
+		return nil, newError(ErrorArity, "if requires 3 arguments")
+	}
+	b, err := r.stepBool(ctx, expr.Name, expr.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	if b {
+		return expr.Args[1], nil
+	}
+	return expr.Args[2], nil
+}, "module: (if (gt x 2) 5 6) - return 5 if x > 2 and 6 otherwise, only the chosen branch is evaluated")
@@ -0,0 +1,57 @@
+package fp
+
+import (
+	"testing"
+)
+
+// TestLogic : comparisons chain, numbers compare across types, and/or/if short-circuit
+func TestLogic(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`true`, Bool(true)},
+		{`(type false)`, String("Bool")},
+		{`(lt 1 2 3)`, Bool(true)},
+		{`(lt 1 3 2)`, Bool(false)},
+		{`(le 1 1 2)`, Bool(true)},
+		{`(gt 3 2 1)`, Bool(true)},
+		{`(ge 2 2 3)`, Bool(false)},
+		{`(lt 1 1.5 99999999999999999999)`, Bool(true)},
+		{`(gt 9007199254740993 9007199254740992.0)`, Bool(true)},
+		{`(lt "abc" "abd")`, Bool(true)},
+		{`(lt (list 1 2) (list 1 2 0))`, Bool(true)},
+		{`(eq 1 1.0 (sub 2 1))`, Bool(true)},
+		{`(eq (list 1 "a") (list 1 "a"))`, Bool(true)},
+		{`(ne 1 2)`, Bool(true)},
+		{`(not (eq 1 2))`, Bool(true)},
+		{`(and true (lt 1 2))`, Bool(true)},
+		{`(and false (kaboom))`, Bool(false)},
+		{`(or true (kaboom))`, Bool(true)},
+		{`(or false false)`, Bool(false)},
+		{`(if (lt 1 2) "yes" (kaboom))`, String("yes")},
+		{`(if false (kaboom) "no")`, String("no")},
+	}
+	for _, c := range tests {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+// TestLogicErrors : conditions must be Bool and values must be ordered
+func TestLogicErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(if 1 2 3)`, ErrorTypeMismatch},
+		{`(and true 1)`, ErrorTypeMismatch},
+		{`(not 0)`, ErrorTypeMismatch},
+		{`(lt 1 "a")`, ErrorTypeMismatch},
+		{`(lt 1.0 (sqrt -1))`, ErrorTypeMismatch},
+		{`(lt 1)`, ErrorArity},
+		{`(if true 1)`, ErrorArity},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
@@ -0,0 +1,123 @@
+package fp
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// gensymCounter : suffix of the last symbol made by gensym, shared by all runtimes so that symbols never collide
+var gensymCounter atomic.Uint64
+
+// tooManyExpansions : error of a macro expanding into macro calls without end
+func tooManyExpansions() error {
+	return fmt.Errorf("%w: more than %d consecutive macro expansions", StackOverflowError, MAX_MACRO_EXPANSIONS)
+}
+
+// expand : expression returned by a macro applied to the unevaluated arguments of site
+func (r *Runtime) expand(ctx context.Context, site LambdaExpr, m Macro) (Expr, error) {
+	var args []Object
+	for _, arg := range site.Args {
+		args = append(args, Quoted{Expr: arg})
+	}
+	v, err := r.call(ctx, site, m.Transformer, args...)
+	if err != nil {
+		return nil, err
+	}
+	return toExpr(v, site.Span), nil
+}
+
+// makeMacro : macro from the arguments of lambda, params ... body
+func makeMacro(ctx context.Context, r *Runtime, expr LambdaExpr) (Macro, error) {
+	if len(expr.Args) == 0 {
+		return Macro{}, newError(ErrorArity, "%s requires a body", expr.Name.Name)
+	}
+	v, err := lambdaModule.Exec(ctx, r, expr)
+	if err != nil {
+		return Macro{}, err
+	}
+	return Macro{Transformer: v.(Lambda)}, nil
+}
+
+var macroModule = Module{
+	Name: "macro",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		return makeMacro(ctx, r, expr)
+	},
+	Man: "module: (macro c x (quasiquote (if (unquote c) (unquote x) 0))) - declare a macro, its parameters are the unevaluated arguments of a call and the expression it returns is evaluated in place of the call",
+}
+
+var defmacroModule = Module{
+	Name: "defmacro",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) < 2 {
+			return nil, newError(ErrorArity, "defmacro requires a name and a body")
+		}
+		name, err := nameArg("defmacro", expr.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		expr.Args = expr.Args[1:]
+		m, err := makeMacro(ctx, r, expr)
+		if err != nil {
+			return nil, err
+		}
+		r.writableFrame(len(r.Stack) - 1)[name] = m
+		return m, nil
+	},
+	Man: "module: (defmacro when c x (quasiquote (if (unquote c) (unquote x) 0))) - declare a macro and assign it to when, same as (let when (macro ...))",
+}
+
+var macroexpandModule = Module{
+	Name: "macroexpand",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		values, err := r.stepArgs(ctx, expr)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "macroexpand requires 1 argument")
+		}
+		e := toExpr(values[0], expr.Span)
+		for expansions := 0; ; expansions++ {
+			call, ok := e.(LambdaExpr)
+			if !ok {
+				break
+			}
+			f, err := r.searchOnStack(String(call.Name.Name))
+			if err != nil {
+				break
+			}
+			m, ok := f.(Macro)
+			if !ok {
+				break
+			}
+			if expansions >= MAX_MACRO_EXPANSIONS {
+				return nil, tooManyExpansions()
+			}
+			if e, err = r.expand(ctx, call, m); err != nil {
+				return nil, err
+			}
+		}
+		return Quoted{Expr: e}, nil
+	},
+	Man: "module: (macroexpand (quote (when c x))) - expression evaluated in place of a macro call, expanded until it is not a macro call",
+}
+
+var gensymExtension = Extension{
+	Name: "gensym",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) > 1 {
+			return nil, newError(ErrorArity, "gensym requires 0 or 1 argument")
+		}
+		prefix := String("g")
+		if len(values) == 1 {
+			var err error
+			if prefix, err = stringArg("gensym", values[0]); err != nil {
+				return nil, err
+			}
+		}
+		return Symbol(fmt.Sprintf("%s#%d", prefix, gensymCounter.Add(1))), nil
+	},
+	Man: "module: (gensym \"tmp\") - new symbol tmp#1, tmp#2... each time, names variables introduced by a macro without capturing the names of its caller",
+}
@@ -0,0 +1,65 @@
+package fp
+
+import (
+	"testing"
+)
+
+const controlMacros = `
+(defmacro when c x (quasiquote (if (unquote c) (unquote x) false)))
+(defmacro unless c x (quasiquote (if (unquote c) false (unquote x))))
+(defmacro -> x & forms (foldl forms (lambda acc form (tail
+	(let l (to-list form))
+	(concat (list (peek l 1) acc) (drop l 1))
+)) x))
+(defmacro cond & clauses (case (len clauses)
+	0 false
+	_ (quasiquote (if (unquote (peek clauses 1)) (unquote (peek clauses 2)) (cond (unquote-splicing (drop clauses 2)))))
+))
+(defmacro swap a b (tail
+	(let tmp (gensym "tmp"))
+	(quasiquote (tail (let (unquote tmp) (unquote a)) (let (unquote a) (unquote b)) (let (unquote b) (unquote tmp))))
+))
+`
+
+// TestMacro : control constructs written in fp, arguments are not evaluated, gensym avoids capture
+func TestMacro(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(when (eq 1 1) 5)`, Int(5)},
+		{`(when (eq 1 2) (kaboom))`, Bool(false)},
+		{`(unless (eq 1 2) 7)`, Int(7)},
+		{`(-> 10 (sub 3) (mul 2) (str "x"))`, String("14x")},
+		{`(let x 5) (cond (lt x 0) "neg" (eq x 0) "zero" true "pos")`, String("pos")},
+		{`(let tmp 1) (let y 2) (swap tmp y) (list tmp y)`, List{Int(2), Int(1)}},
+		{`(macroexpand (quote (when c x)))`, Quoted{Expr: LambdaExpr{
+			Name: NameExpr{Name: "if"},
+			Args: []Expr{NameExpr{Name: "c"}, NameExpr{Name: "x"}, NameExpr{Name: "false"}},
+		}}},
+		{`(let f (lambda n (when (gt n 0) (f (sub n 1))))) (f 100000)`, Bool(false)},
+		{`(type (macro x x))`, String("Macro")},
+	}
+	for _, c := range tests {
+		r := NewStdRuntime()
+		mustEval(t, r, controlMacros)
+		expect(t, r, c.code, c.want)
+	}
+}
+
+// TestMacroExpansionBounded : macros expanding into themselves fail with StackOverflow instead of crashing
+func TestMacroExpansionBounded(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"non-tail", `(defmacro m x (quasiquote (add 1 (m (unquote x))))) (m 1)`},
+		{"tail", `(defmacro m x (quasiquote (m (unquote x)))) (m 1)`},
+		{"macroexpand", `(defmacro m x (quasiquote (m (unquote x)))) (macroexpand (quote (m 1)))`},
+	}
+	for _, c := range tests {
+		t.Run(c.name, func(t *testing.T) {
+			expectError(t, NewStdRuntime(), c.code, ErrorStackOverflow)
+		})
+	}
+}
@@ -2,9 +2,9 @@ package fp
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"time"
+	"unicode/utf8"
 )
 
 type Extension struct {
@@ -13,35 +13,71 @@ type Extension struct {
 	Man  string
 }
 
+// stepArgs : evaluate arguments of a call, * unwraps the next argument
+func (r *Runtime) stepArgs(ctx context.Context, expr LambdaExpr) ([]Object, error) {
+	args, err := r.stepMany(ctx, expr.Args...)
+	if err != nil {
+		return nil, err
+	}
+	var unwrappedArgs []Object
+	i := 0
+	for i < len(args) {
+		if _, ok := args[i].(Unwrap); ok {
+			if i+1 >= len(args) {
+				return nil, newError(ErrorTypeMismatch, "unwrapping arguments must be a list or a dict")
+			}
+			switch argsList := args[i+1].(type) {
+			case List:
+				unwrappedArgs = append(unwrappedArgs, argsList...)
+			case Dict:
+				// key value pairs ordered by key
+				for _, entry := range argsList.sortedEntries() {
+					unwrappedArgs = append(unwrappedArgs, entry.Key, entry.Value)
+				}
+			default:
+				return nil, newError(ErrorTypeMismatch, "unwrapping arguments must be a list or a dict")
+			}
+			i += 2
+		} else {
+			unwrappedArgs = append(unwrappedArgs, args[i])
+			i++
+		}
+	}
+	return unwrappedArgs, nil
+}
+
+// makeTailModule : module whose result is the value of the expression returned by tail, Step evaluates that expression
+// in tail position
+func makeTailModule(name String, tail func(ctx context.Context, r *Runtime, expr LambdaExpr) (Expr, error), man string) Module {
+	return Module{
+		Name: name,
+		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+			next, err := tail(ctx, r, expr)
+			if err != nil {
+				return nil, err
+			}
+			return r.Step(ctx, next)
+		},
+		Tail: tail,
+		Man:  man,
+	}
+}
+
 func makeModuleFromExtension(e Extension) Module {
 	return Module{
 		Name: e.Name,
-		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
-			args, err := r.stepMany(ctx, expr.Args...)
+		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (v Object, err error) {
+			args, err := r.stepArgs(ctx, expr)
 			if err != nil {
 				return nil, err
 			}
-			var unwrappedArgs []Object
-			i := 0
-			for i < len(args) {
-				if _, ok := args[i].(Unwrap); ok {
-					if i+1 >= len(args) {
-						return nil, errors.New("unwrapping arguments must be a list")
-					}
-					argsList, ok := args[i+1].(List)
-					if !ok {
-						return nil, errors.New("unwrapping arguments must be a list")
-					}
-					for _, elem := range argsList {
-						unwrappedArgs = append(unwrappedArgs, elem)
-					}
-					i += 2
-				} else {
-					unwrappedArgs = append(unwrappedArgs, args[i])
-					i++
+			// a bug in an extension must not crash the program evaluating untrusted code
+			defer func() {
+				if p := recover(); p != nil {
+					v, err = nil, newError(ErrorRuntime, "%s panicked: %v", e.Name, p)
 				}
-			}
-			return e.Exec(ctx, unwrappedArgs...)
+			}()
+			return e.Exec(withLimits(ctx, r.Limits), args...)
 		},
 		Man: e.Man,
 	}
@@ -55,14 +91,17 @@ var letModule = Module{
 	Name: "let",
 	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
 		if len(expr.Args) < 2 {
-			return nil, fmt.Errorf("not enough arguments for let")
+			return nil, newError(ErrorArity, "not enough arguments for let")
+		}
+		name, err := nameArg("let", expr.Args[0])
+		if err != nil {
+			return nil, err
 		}
-		name := String(expr.Args[0].(NameExpr))
 		outputs, err := r.stepMany(ctx, expr.Args[1:]...)
 		if err != nil {
 			return nil, err
 		}
-		r.Stack[len(r.Stack)-1][name] = outputs[len(outputs)-1]
+		r.writableFrame(len(r.Stack) - 1)[name] = outputs[len(outputs)-1]
 		return outputs[len(outputs)-1], nil
 	},
 	Man: "module: (let x 3) - assign value 3 to local variable x",
@@ -72,14 +111,17 @@ var delModule = Module{
 	Name: "del",
 	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
 		if len(expr.Args) < 1 {
-			return nil, fmt.Errorf("not enough arguments for del")
+			return nil, newError(ErrorArity, "not enough arguments for del")
+		}
+		name, err := nameArg("del", expr.Args[0])
+		if err != nil {
+			return nil, err
 		}
-		name := String(expr.Args[0].(NameExpr))
-		_, err := r.stepMany(ctx, expr.Args[1:]...)
+		_, err = r.stepMany(ctx, expr.Args[1:]...)
 		if err != nil {
 			return nil, err
 		}
-		delete(r.Stack[len(r.Stack)-1], name)
+		delete(r.writableFrame(len(r.Stack)-1), name)
 		return nil, nil
 	},
 	Man: "module: (del x) - delete variable x",
@@ -88,48 +130,78 @@ var delModule = Module{
 var lambdaModule = Module{
 	Name: "lambda",
 	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) == 0 {
+			return nil, newError(ErrorArity, "lambda requires a body")
+		}
 		v := Lambda{
 			Params: nil,
 			Impl:   nil,
-			Frame:  nil,
-		}
-		for i := 0; i < len(expr.Args)-1; i++ {
-			paramName := String(expr.Args[i].(NameExpr))
-			v.Params = append(v.Params, paramName)
+			Env:    nil,
+		}
+		if body, ok := expr.Args[len(expr.Args)-1].(NameExpr); ok && body.Name == "&" {
+			// (lambda x &) would take & as the body
+			return nil, newError(ErrorTypeMismatch, "lambda requires a rest parameter and a body after &")
+		}
+		params := expr.Args[:len(expr.Args)-1]
+		for i := 0; i < len(params); i++ {
+			switch param := params[i].(type) {
+			case NameExpr:
+				if param.Name == "&" {
+					if i != len(params)-2 {
+						return nil, newError(ErrorTypeMismatch, "lambda requires exactly one rest parameter after &")
+					}
+					rest, err := nameArg("lambda", params[i+1])
+					if err != nil {
+						return nil, err
+					}
+					if rest == "&" {
+						return nil, newError(ErrorTypeMismatch, "lambda requires exactly one rest parameter after &")
+					}
+					v.Rest = rest
+					i++
+					continue
+				}
+				if len(v.Defaults) > 0 {
+					return nil, newError(ErrorTypeMismatch, "lambda parameter %s without default value after an optional parameter", param.Name)
+				}
+				v.Params = append(v.Params, String(param.Name))
+			case LambdaExpr:
+				// optional parameter (y 2)
+				if len(param.Args) != 1 {
+					return nil, newError(ErrorTypeMismatch, "lambda optional parameter requires 1 default value but got %s", param)
+				}
+				v.Params = append(v.Params, String(param.Name.Name))
+				v.Defaults = append(v.Defaults, param.Args[0])
+			default:
+				return nil, newError(ErrorTypeMismatch, "lambda requires a name but got %s", param)
+			}
 		}
 		v.Impl = expr.Args[len(expr.Args)-1]
-		v.Frame = make(Frame).Update(r.Stack[len(r.Stack)-1])
+		v.Env = r.closure()
 		return v, nil
 	},
-	Man: "module: (lambda x y (add x y) - declare a function",
+	Man: "module: (lambda x (y 2) & rest (add x y * rest)) - declare a function, y is optional with default value 2 and rest is the list of extra arguments",
 }
 
-var caseModule = Module{
-	Name: "case",
-	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
-		cond, err := r.Step(ctx, expr.Args[0])
+var caseModule = makeTailModule("case", func(ctx context.Context, r *Runtime, expr LambdaExpr) (Expr, error) {
+	if len(expr.Args) == 0 {
+		return nil, newError(ErrorArity, "case requires at least 1 argument")
+	}
+	cond, err := r.Step(ctx, expr.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	for i := 1; i+1 < len(expr.Args); i += 2 {
+		comp, err := r.Step(ctx, expr.Args[i])
 		if err != nil {
 			return nil, err
 		}
-		i, err := func() (int, error) {
-			for i := 1; i < len(expr.Args); i += 2 {
-				comp, err := r.Step(ctx, expr.Args[i])
-				if err != nil {
-					return 0, err
-				}
-				if _, ok := comp.(Wildcard); ok || comp == cond {
-					return i, nil
-				}
-			}
-			return 0, fmt.Errorf("runtime error: no case matched %s", expr)
-		}()
-		if err != nil {
-			return nil, err
+		if _, ok := comp.(Wildcard); ok || Equal(comp, cond) {
+			return expr.Args[i+1], nil
 		}
-		return r.Step(ctx, expr.Args[i+1])
-	},
-	Man: "module: (case x 1 2 4 5) - case, if x=1 then return 3, if x=4 the return 5",
-}
+	}
+	return nil, newError(ErrorNoCaseMatched, "no case matched %s", expr)
+}, "module: (case x 1 2 4 5) - case, if x=1 then return 3, if x=4 the return 5")
 
 var kaboomModule = Module{
 	Name: "kaboom",
@@ -148,24 +220,30 @@ var doomExtension = Extension{
 	Man: "module: (doom) - extra modules required https://youtu.be/dQw4w9WgXcQ",
 }
 
-var tailExtension = Extension{
-	Name: "tail",
-	Exec: func(ctx context.Context, values ...Object) (Object, error) {
-		return values[len(values)-1], nil
-	},
-	Man: "module: (tail (print 1) (print 2) 3) - exec a sequence of expressions and return the last one",
-}
+var tailModule = makeTailModule("tail", func(ctx context.Context, r *Runtime, expr LambdaExpr) (Expr, error) {
+	if len(expr.Args) == 0 {
+		return nil, newError(ErrorArity, "tail requires at least 1 argument")
+	}
+	if _, err := r.stepMany(ctx, expr.Args[:len(expr.Args)-1]...); err != nil {
+		return nil, err
+	}
+	return expr.Args[len(expr.Args)-1], nil
+}, "module: (tail (print 1) (print 2) 3) - exec a sequence of expressions and return the last one")
 
 var addExtension = Extension{
 	Name: "add",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
-		var sum Int = 0
+		var sum Object = Int(0)
 		for i := 0; i < len(values); i++ {
-			v, ok := values[i].(Int)
+			var ok bool
+			var err error
+			sum, ok, err = addOp.apply(ctx, sum, values[i])
 			if !ok {
-				return nil, fmt.Errorf("adding non-integer values")
+				return nil, newError(ErrorTypeMismatch, "adding non-numeric values")
+			}
+			if err != nil {
+				return nil, err
 			}
-			sum += v
 		}
 		return sum, nil
 	},
@@ -175,13 +253,17 @@ var addExtension = Extension{
 var mulExtension = Extension{
 	Name: "mul",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
-		var sum Int = 1
+		var sum Object = Int(1)
 		for i := 0; i < len(values); i++ {
-			v, ok := values[i].(Int)
+			var ok bool
+			var err error
+			sum, ok, err = mulOp.apply(ctx, sum, values[i])
 			if !ok {
-				return nil, fmt.Errorf("multiplying non-integer values")
+				return nil, newError(ErrorTypeMismatch, "multiplying non-numeric values")
+			}
+			if err != nil {
+				return nil, err
 			}
-			sum *= v
 		}
 		return sum, nil
 	},
@@ -192,17 +274,13 @@ var subExtension = Extension{
 	Name: "sub",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) != 2 {
-			return nil, fmt.Errorf("subtract requires 2 arguments")
+			return nil, newError(ErrorArity, "subtract requires 2 arguments")
 		}
-		a, ok := values[0].(Int)
+		v, ok, err := subOp.apply(ctx, values[0], values[1])
 		if !ok {
-			return nil, fmt.Errorf("subtract non-integer value")
+			return nil, newError(ErrorTypeMismatch, "subtract non-numeric value")
 		}
-		b, ok := values[1].(Int)
-		if !ok {
-			return nil, fmt.Errorf("subtract non-integer value")
-		}
-		return a - b, nil
+		return v, err
 	},
 	Man: "module: (sub 2 (add 1 1)) - exec two expressions and return difference",
 }
@@ -211,42 +289,28 @@ var divExtension = Extension{
 	Name: "div",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) != 2 {
-			return nil, fmt.Errorf("divide requires 2 arguments")
-		}
-		a, ok := values[0].(Int)
-		if !ok {
-			return nil, fmt.Errorf("divide non-integer value")
+			return nil, newError(ErrorArity, "divide requires 2 arguments")
 		}
-		b, ok := values[1].(Int)
+		v, ok, err := divOp.apply(ctx, values[0], values[1])
 		if !ok {
-			return nil, fmt.Errorf("divide non-integer value")
+			return nil, newError(ErrorTypeMismatch, "divide non-numeric value")
 		}
-		if b == 0 {
-			return nil, fmt.Errorf("division by zero")
-		}
-		return a / b, nil
+		return v, err
 	},
-	Man: "module: (div 2 (add 1 1)) - exec two expressions and return ratio",
+	Man: "module: (div 7 2) - exec two expressions and return ratio, integer division if both are Int, (div 7 2.0) is 3.5",
 }
 
 var modExtension = Extension{
 	Name: "mod",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) != 2 {
-			return nil, fmt.Errorf("dividing requires 2 arguments")
-		}
-		a, ok := values[0].(Int)
-		if !ok {
-			return nil, fmt.Errorf("dividing non-integer value")
+			return nil, newError(ErrorArity, "dividing requires 2 arguments")
 		}
-		b, ok := values[1].(Int)
+		v, ok, err := modOp.apply(ctx, values[0], values[1])
 		if !ok {
-			return nil, fmt.Errorf("dividing non-integer value")
+			return nil, newError(ErrorTypeMismatch, "dividing non-numeric value")
 		}
-		if b == 0 {
-			return nil, fmt.Errorf("division by zero")
-		}
-		return a % b, nil
+		return v, err
 	},
 	Man: "module: (mod 2 (add 1 1)) - exec two expressions and return modulo",
 }
@@ -254,9 +318,15 @@ var modExtension = Extension{
 var signExtension = Extension{
 	Name: "sign",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
-		v, ok := values[len(values)-1].(Int)
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "sign requires 1 argument")
+		}
+		if v, ok := values[0].(BigInt); ok {
+			return Int(v.Value.Sign()), nil
+		}
+		v, ok := toFloat(values[0])
 		if !ok {
-			return nil, fmt.Errorf("sign non-integer value")
+			return nil, newError(ErrorTypeMismatch, "sign non-numeric value")
 		}
 		switch {
 		case v > 0:
@@ -285,9 +355,12 @@ var listExtension = Extension{
 var appendExtension = Extension{
 	Name: "append",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) < 1 {
+			return nil, newError(ErrorArity, "append requires a list")
+		}
 		l, ok := values[0].(List)
 		if !ok {
-			return nil, fmt.Errorf("first argument must be list")
+			return nil, newError(ErrorTypeMismatch, "first argument must be list")
 		}
 		return append(l, values[1:]...), nil
 	},
@@ -298,26 +371,26 @@ var sliceExtension = Extension{
 	Name: "slice",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) != 3 {
-			return nil, fmt.Errorf("slice requires 3 arguments")
+			return nil, newError(ErrorArity, "slice requires 3 arguments")
 		}
 		l, ok := values[0].(List)
 		if !ok {
-			return nil, fmt.Errorf("first argument must be list")
+			return nil, newError(ErrorTypeMismatch, "first argument must be list")
 		}
 		if len(l) < 1 {
-			return nil, fmt.Errorf("empty list")
+			return nil, newError(ErrorRuntime, "empty list")
 		}
 		i, ok := values[1].(Int)
 		if !ok {
-			return nil, fmt.Errorf("second argument must be integer")
+			return nil, newError(ErrorTypeMismatch, "second argument must be integer")
 		}
 		j, ok := values[2].(Int)
 		if !ok {
-			return nil, fmt.Errorf("third argument must be integer")
+			return nil, newError(ErrorTypeMismatch, "third argument must be integer")
 		}
 		length := Int(len(l))
 		if i-1 < 0 || i-1 >= length || j < 0 || j > length {
-			return nil, fmt.Errorf("list is out of range")
+			return nil, newError(ErrorRuntime, "list is out of range")
 		}
 		return l[i-1 : j], nil
 	},
@@ -328,24 +401,24 @@ var peekExtension = Extension{
 	Name: "peek",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) < 2 {
-			return nil, fmt.Errorf("peak requires at least 2 arguments")
+			return nil, newError(ErrorArity, "peak requires at least 2 arguments")
 		}
 		l, ok := values[0].(List)
 		if !ok {
-			return nil, fmt.Errorf("first argument must be list")
+			return nil, newError(ErrorTypeMismatch, "first argument must be list")
 		}
 		length := Int(len(l))
 		if length < 1 {
-			return nil, fmt.Errorf("empty list")
+			return nil, newError(ErrorRuntime, "empty list")
 		}
 		var outputs List
 		for j := 1; j < len(values); j++ {
 			i, ok := values[j].(Int)
 			if !ok {
-				return nil, fmt.Errorf("second argument must be integer")
+				return nil, newError(ErrorTypeMismatch, "second argument must be integer")
 			}
 			if i < 1 || i > length {
-				return nil, fmt.Errorf("list is out of range")
+				return nil, newError(ErrorRuntime, "list is out of range")
 			}
 			outputs = append(outputs, l[i-1])
 		}
@@ -361,105 +434,41 @@ var lenExtension = Extension{
 	Name: "len",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) != 1 {
-			return nil, fmt.Errorf("len requires 1 argument")
+			return nil, newError(ErrorArity, "len requires 1 argument")
 		}
 		switch v := values[0].(type) {
 		case List:
 			return Int(len(v)), nil
 		case Dict:
-			return Int(len(v)), nil
-		default:
-			return nil, fmt.Errorf("first argument must be list or dict")
-		}
-	},
-	Man: "module: (len l) - get length of a list of dict",
-}
-
-// mapModule - TODO make map parallel by make a copy of the latest frame, reuse other frames, call in parallel
-var mapModule = Module{
-	Name: "map",
-	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
-		if len(expr.Args) != 2 {
-			return nil, fmt.Errorf("map requires 2 arguments")
-		}
-		l1, err := r.Step(ctx, expr.Args[0])
-		if err != nil {
-			return nil, err
-		}
-		l, ok := l1.(List)
-		if !ok {
-			return nil, fmt.Errorf("first argument must be list")
-		}
-		f1, err := r.Step(ctx, expr.Args[1])
-		if err != nil {
-			return nil, err
-		}
-		var outputs List
-		switch f := f1.(type) {
-		case Lambda:
-			if len(f.Params) != 1 {
-				return nil, fmt.Errorf("map function requires 1 argument")
-			}
-			for _, v := range l {
-				// 2. add argument to local Frame
-				localFrame := make(Frame).Update(f.Frame)
-				localFrame[f.Params[0]] = v
-				// 3. push Frame to Stack
-				r.Stack = append(r.Stack, localFrame)
-				// 4. exec function
-				o, err := r.Step(ctx, f.Impl)
-				// 5. pop Frame from Stack
-				r.Stack = r.Stack[:len(r.Stack)-1]
-				// 6. append o
-				if err != nil {
-					return nil, err
-				}
-				outputs = append(outputs, o)
-			}
-		case Module:
-			for _, v := range l {
-				// 2. add argument to local Frame
-				localFrame := make(Frame)
-				localFrame["x"] = v // dummy variable
-				// 3. make dummy expr and exec
-				o, err := f.Exec(ctx, r, LambdaExpr{
-					Name: "",
-					Args: []Expr{NameExpr("x")}, // dummy variable
-				})
-				// 5. pop Frame from Stack
-				r.Stack = r.Stack[:len(r.Stack)-1]
-				// 6. append o
-				if err != nil {
-					return nil, err
-				}
-				outputs = append(outputs, o)
-			}
+			return Int(v.Len()), nil
+		case String:
+			return Int(utf8.RuneCountInString(string(v))), nil
 		default:
-			return nil, fmt.Errorf("runtime error: map module requires a function")
+			return nil, newError(ErrorTypeMismatch, "first argument must be list, dict or string")
 		}
-		return outputs, nil
 	},
-	Man: "module: (map l (lambda y (add 1 y))) - map or for loop",
+	Man: "module: (len l) - get length of a list or dict, number of characters of a string",
 }
 
-// TODO - implement map filter reduce
-
 var rangeExtension = Extension{
 	Name: "range",
 	Exec: func(ctx context.Context, values ...Object) (Object, error) {
 		if len(values) < 2 {
-			return nil, fmt.Errorf("range requires at least 2 arguments")
+			return nil, newError(ErrorArity, "range requires at least 2 arguments")
 		}
 		low, ok := values[0].(Int)
 		if !ok {
-			return nil, fmt.Errorf("first argument must be integer")
+			return nil, newError(ErrorTypeMismatch, "first argument must be integer")
 		}
 		high, ok := values[1].(Int)
 		if !ok {
-			return nil, fmt.Errorf("second argument must be integer")
+			return nil, newError(ErrorTypeMismatch, "second argument must be integer")
 		}
 		if low > high {
-			return nil, nil
+			return List{}, nil
+		}
+		if err := LimitsFromContext(ctx).checkLength(int(high-low) + 1); err != nil {
+			return nil, err
 		}
 		var list List
 		for i := low; i <= high; i++ {
@@ -467,7 +476,7 @@ var rangeExtension = Extension{
 		}
 		return list, nil
 	},
-	Man: "module: (range 1 10) - return [1, 2, ..., 10]",
+	Man: "module: (range 1 10) - return [1, 2, ..., 10], empty if 10 < 1",
 }
 
 var typeExtension = Extension{
@@ -489,10 +498,10 @@ var stackModule = Module{
 	Name: "stack",
 	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
 		var stack List
-		for _, f := range r.Stack {
-			frame := make(Dict)
-			for k, v := range f {
-				frame[String(k)] = v
+		for _, e := range r.Stack {
+			var frame Dict
+			for k, v := range e.Frame {
+				frame.Set(k, v)
 			}
 			stack = append(stack, frame)
 		}
@@ -501,22 +510,14 @@ var stackModule = Module{
 	Man: "module: (stack) - get stack",
 }
 
-var printExtension = Extension{
-	Name: "print",
-	Exec: func(ctx context.Context, values ...Object) (Object, error) {
-		for _, v := range values {
-			fmt.Printf("%v ", v)
-		}
-		fmt.Println()
-		return Int(len(values)), nil
-	},
-	Man: "module: (print 1 x (lambda 3)) - print values",
-}
-
-var timeExtension = Extension{
+var timeModule = Module{
 	Name: "time",
-	Exec: func(ctx context.Context, values ...Object) (Object, error) {
-		return Int(time.Now().UnixNano()), nil
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		now := r.clock
+		if now == nil {
+			now = time.Now
+		}
+		return Int(now().UnixNano()), nil
 	},
 	Man: "(time) - get current time",
 }
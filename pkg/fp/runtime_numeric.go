@@ -0,0 +1,459 @@
+package fp
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// numericOp : binary operator on the numeric tower Int < BigInt < Float, both operands are promoted to the larger type
+//
+// Fp is outside the tower, an Int operand is lifted into the field of the other operand
+type numericOp struct {
+	Int   func(a Int, b Int) (Object, error)
+	Big   func(a *big.Int, b *big.Int) (Object, error)
+	Float func(a Float, b Float) (Object, error)
+	Fp    func(a Fp, b Fp) (Object, error) // nil if the operator is not defined on Fp
+}
+
+// normalizeBig : BigInt is only used when the value does not fit in Int
+func normalizeBig(v *big.Int) Object {
+	if v.IsInt64() && v.Int64() >= math.MinInt && v.Int64() <= math.MaxInt {
+		return Int(v.Int64())
+	}
+	return BigInt{Value: v}
+}
+
+// toBig : promote an integer value to *big.Int
+func toBig(o Object) (*big.Int, bool) {
+	switch o := o.(type) {
+	case Int:
+		return big.NewInt(int64(o)), true
+	case BigInt:
+		return o.Value, true
+	default:
+		return nil, false
+	}
+}
+
+// toFloat : promote a numeric value to Float
+func toFloat(o Object) (Float, bool) {
+	switch o := o.(type) {
+	case Int:
+		return Float(o), true
+	case BigInt:
+		f, _ := new(big.Float).SetInt(o.Value).Float64()
+		return Float(f), true
+	case Float:
+		return o, true
+	default:
+		return 0, false
+	}
+}
+
+// toBigFloat : exact value of a numeric value, Float must not be NaN
+func toBigFloat(o Object) *big.Float {
+	switch o := o.(type) {
+	case Int:
+		return new(big.Float).SetInt64(int64(o))
+	case BigInt:
+		return new(big.Float).SetInt(o.Value)
+	case Float:
+		return big.NewFloat(float64(o))
+	default:
+		return nil
+	}
+}
+
+// toFp : lift an integer value into the field of order p
+func toFp(o Object, p *big.Int) (Fp, bool) {
+	switch o := o.(type) {
+	case Fp:
+		return o, o.P.Cmp(p) == 0
+	default:
+		v, ok := toBig(o)
+		if !ok {
+			return Fp{}, false
+		}
+		return NewFp(v, p), true
+	}
+}
+
+// apply : ok is false if one of the operands is not numeric, integer results are checked against the limits of ctx
+func (op numericOp) apply(ctx context.Context, a Object, b Object) (v Object, ok bool, err error) {
+	v, ok, err = op.compute(a, b)
+	if i, isBig := v.(BigInt); isBig && err == nil {
+		err = LimitsFromContext(ctx).checkBits(i.Value.BitLen())
+	}
+	return v, ok, err
+}
+
+// compute : result of the operator on the promoted operands, ok is false if one of them is not numeric
+func (op numericOp) compute(a Object, b Object) (v Object, ok bool, err error) {
+	if a, ok := a.(Int); ok {
+		if b, ok := b.(Int); ok {
+			v, err := op.Int(a, b)
+			return v, true, err
+		}
+	}
+	var p *big.Int
+	if fa, ok := a.(Fp); ok {
+		p = fa.P
+	} else if fb, ok := b.(Fp); ok {
+		p = fb.P
+	}
+	if p != nil {
+		if op.Fp == nil {
+			return nil, false, nil
+		}
+		fa, ok := toFp(a, p)
+		if !ok {
+			return nil, false, nil
+		}
+		fb, ok := toFp(b, p)
+		if !ok {
+			return nil, false, nil
+		}
+		v, err = op.Fp(fa, fb)
+		return v, true, err
+	}
+	if ba, ok := toBig(a); ok {
+		if bb, ok := toBig(b); ok {
+			v, err = op.Big(ba, bb)
+			return v, true, err
+		}
+	}
+	fa, ok := toFloat(a)
+	if !ok {
+		return nil, false, nil
+	}
+	fb, ok := toFloat(b)
+	if !ok {
+		return nil, false, nil
+	}
+	v, err = op.Float(fa, fb)
+	return v, true, err
+}
+
+func bigAdd(a *big.Int, b *big.Int) (Object, error) {
+	return normalizeBig(new(big.Int).Add(a, b)), nil
+}
+
+func bigSub(a *big.Int, b *big.Int) (Object, error) {
+	return normalizeBig(new(big.Int).Sub(a, b)), nil
+}
+
+func bigMul(a *big.Int, b *big.Int) (Object, error) {
+	return normalizeBig(new(big.Int).Mul(a, b)), nil
+}
+
+var addOp = numericOp{
+	Int: func(a Int, b Int) (Object, error) {
+		c := a + b
+		if (c > a) != (b > 0) { // overflow
+			return bigAdd(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		}
+		return c, nil
+	},
+	Big: bigAdd,
+	Float: func(a Float, b Float) (Object, error) {
+		return a + b, nil
+	},
+	Fp: func(a Fp, b Fp) (Object, error) {
+		return NewFp(new(big.Int).Add(a.Value, b.Value), a.P), nil
+	},
+}
+
+var subOp = numericOp{
+	Int: func(a Int, b Int) (Object, error) {
+		c := a - b
+		if (c < a) != (b > 0) { // overflow
+			return bigSub(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		}
+		return c, nil
+	},
+	Big: bigSub,
+	Float: func(a Float, b Float) (Object, error) {
+		return a - b, nil
+	},
+	Fp: func(a Fp, b Fp) (Object, error) {
+		return NewFp(new(big.Int).Sub(a.Value, b.Value), a.P), nil
+	},
+}
+
+var mulOp = numericOp{
+	Int: func(a Int, b Int) (Object, error) {
+		if a == 0 || b == 0 {
+			return Int(0), nil
+		}
+		c := a * b
+		if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) { // overflow
+			return bigMul(big.NewInt(int64(a)), big.NewInt(int64(b)))
+		}
+		return c, nil
+	},
+	Big: bigMul,
+	Float: func(a Float, b Float) (Object, error) {
+		return a * b, nil
+	},
+	Fp: func(a Fp, b Fp) (Object, error) {
+		return NewFp(new(big.Int).Mul(a.Value, b.Value), a.P), nil
+	},
+}
+
+var divOp = numericOp{
+	Int: func(a Int, b Int) (Object, error) {
+		if b == 0 {
+			return nil, newError(ErrorDivisionByZero, "division by zero")
+		}
+		if a == math.MinInt && b == -1 { // overflow
+			return normalizeBig(new(big.Int).Neg(big.NewInt(int64(a)))), nil
+		}
+		return a / b, nil
+	},
+	Big: func(a *big.Int, b *big.Int) (Object, error) {
+		if b.Sign() == 0 {
+			return nil, newError(ErrorDivisionByZero, "division by zero")
+		}
+		return normalizeBig(new(big.Int).Quo(a, b)), nil // truncated like Int
+	},
+	Float: func(a Float, b Float) (Object, error) {
+		if b == 0 {
+			return nil, newError(ErrorDivisionByZero, "division by zero")
+		}
+		return a / b, nil
+	},
+	Fp: func(a Fp, b Fp) (Object, error) {
+		inv, err := b.Inverse()
+		if err != nil {
+			return nil, err
+		}
+		return NewFp(new(big.Int).Mul(a.Value, inv.Value), a.P), nil
+	},
+}
+
+var modOp = numericOp{
+	Int: func(a Int, b Int) (Object, error) {
+		if b == 0 {
+			return nil, newError(ErrorDivisionByZero, "division by zero")
+		}
+		if b == -1 {
+			return Int(0), nil
+		}
+		return a % b, nil
+	},
+	Big: func(a *big.Int, b *big.Int) (Object, error) {
+		if b.Sign() == 0 {
+			return nil, newError(ErrorDivisionByZero, "division by zero")
+		}
+		return normalizeBig(new(big.Int).Rem(a, b)), nil // same sign as a like Int
+	},
+	Float: func(a Float, b Float) (Object, error) {
+		if b == 0 {
+			return nil, newError(ErrorDivisionByZero, "division by zero")
+		}
+		return Float(math.Mod(float64(a), float64(b))), nil
+	},
+}
+
+// makeRoundingExtension : Int is returned as is, Float is rounded to Int
+func makeRoundingExtension(name String, round func(float64) float64, man string) Extension {
+	return Extension{
+		Name: name,
+		Exec: func(ctx context.Context, values ...Object) (Object, error) {
+			if len(values) != 1 {
+				return nil, newError(ErrorArity, "%s requires 1 argument", name)
+			}
+			switch v := values[0].(type) {
+			case Int, BigInt:
+				return v, nil
+			case Float:
+				f := round(float64(v))
+				if math.IsNaN(f) || math.IsInf(f, 0) {
+					return nil, newError(ErrorRuntime, "%s of %s is not an integer", name, v)
+				}
+				i, _ := big.NewFloat(f).Int(nil)
+				return normalizeBig(i), nil
+			default:
+				return nil, newError(ErrorTypeMismatch, "%s non-numeric value", name)
+			}
+		},
+		Man: man,
+	}
+}
+
+// makeFloatExtension : numeric arguments are promoted to Float
+func makeFloatExtension(name String, f func(float64) float64, man string) Extension {
+	return Extension{
+		Name: name,
+		Exec: func(ctx context.Context, values ...Object) (Object, error) {
+			if len(values) != 1 {
+				return nil, newError(ErrorArity, "%s requires 1 argument", name)
+			}
+			v, ok := toFloat(values[0])
+			if !ok {
+				return nil, newError(ErrorTypeMismatch, "%s non-numeric value", name)
+			}
+			return Float(f(float64(v))), nil
+		},
+		Man: man,
+	}
+}
+
+var floorExtension = makeRoundingExtension("floor", math.Floor, "module: (floor 3.7) - round down to Int")
+
+var ceilExtension = makeRoundingExtension("ceil", math.Ceil, "module: (ceil 3.2) - round up to Int")
+
+var roundExtension = makeRoundingExtension("round", math.Round, "module: (round 3.5) - round half away from zero to Int")
+
+var sqrtExtension = makeFloatExtension("sqrt", math.Sqrt, "module: (sqrt 2) - square root")
+
+var expExtension = makeFloatExtension("exp", math.Exp, "module: (exp 1) - e to the power of x")
+
+var logExtension = Extension{
+	Name: "log",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, newError(ErrorArity, "log requires 1 or 2 arguments")
+		}
+		x, ok := toFloat(values[0])
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "log non-numeric value")
+		}
+		if len(values) == 1 {
+			return Float(math.Log(float64(x))), nil
+		}
+		base, ok := toFloat(values[1])
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "log non-numeric base")
+		}
+		return Float(math.Log(float64(x)) / math.Log(float64(base))), nil
+	},
+	Man: "module: (log 100 10) - logarithm of x, natural logarithm if base is omitted",
+}
+
+// maxPowBits : bit length of the largest integer pow computes, Exp does not stop until it is done
+const maxPowBits = 1 << 24
+
+// powTooLarge : a to the power of b has more than maxBits bits, checked before computing it
+func powTooLarge(a *big.Int, b *big.Int, maxBits int) bool {
+	bits := new(big.Int).Abs(a).BitLen() - 1 // a^b has more than bits*b bits
+	if bits <= 0 {
+		return false // 0, 1 and -1
+	}
+	return !b.IsInt64() || b.Int64() > int64(maxBits/bits)
+}
+
+var powExtension = Extension{
+	Name: "pow",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 {
+			return nil, newError(ErrorArity, "pow requires 2 arguments")
+		}
+		if a, ok := values[0].(Fp); ok {
+			b, ok := toBig(values[1])
+			if !ok {
+				return nil, newError(ErrorTypeMismatch, "exponent of Fp must be integer")
+			}
+			return a.Pow(b)
+		}
+		if a, ok := toBig(values[0]); ok {
+			if b, ok := toBig(values[1]); ok && b.Sign() >= 0 {
+				if limit := LimitsFromContext(ctx).MaxIntBits; limit > 0 && powTooLarge(a, b, limit) {
+					return nil, fmt.Errorf("%w: pow result of more than %d bits", IntSizeLimitError, limit)
+				}
+				if powTooLarge(a, b, maxPowBits) {
+					return nil, newError(ErrorRuntime, "pow result has more than %d bits", maxPowBits)
+				}
+				// exponentiation by squaring
+				return normalizeBig(new(big.Int).Exp(a, b, nil)), nil
+			}
+		}
+		a, ok := toFloat(values[0])
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "pow non-numeric value")
+		}
+		b, ok := toFloat(values[1])
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "pow non-numeric value")
+		}
+		return Float(math.Pow(float64(a), float64(b))), nil
+	},
+	Man: "module: (pow 2 10) - x to the power of y, Int if both are Int and y is non-negative (error past 2^24 bits), Fp if x is Fp",
+}
+
+// fieldArgs : (f x) with x of type Fp or (f x p) with integers x, p
+func fieldArgs(name String, values ...Object) (Fp, error) {
+	switch len(values) {
+	case 1:
+		x, ok := values[0].(Fp)
+		if !ok {
+			return Fp{}, newError(ErrorTypeMismatch, "%s requires Fp or 2 integers", name)
+		}
+		return x, nil
+	case 2:
+		x, ok := toBig(values[0])
+		if !ok {
+			return Fp{}, newError(ErrorTypeMismatch, "%s requires Fp or 2 integers", name)
+		}
+		p, ok := toBig(values[1])
+		if !ok {
+			return Fp{}, newError(ErrorTypeMismatch, "%s requires Fp or 2 integers", name)
+		}
+		if p.Cmp(big.NewInt(2)) < 0 {
+			return Fp{}, newError(ErrorRuntime, "modulus must be at least 2")
+		}
+		return NewFp(x, p), nil
+	default:
+		return Fp{}, newError(ErrorArity, "%s requires 1 or 2 arguments", name)
+	}
+}
+
+var fpExtension = Extension{
+	Name: "fp",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 {
+			return nil, newError(ErrorArity, "fp requires 2 arguments")
+		}
+		return fieldArgs("fp", values...)
+	},
+	Man: "module: (fp 5 7) - integer 5 modulo 7, add sub mul div pow are computed modulo 7",
+}
+
+var legendreExtension = Extension{
+	Name: "legendre",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		x, err := fieldArgs("legendre", values...)
+		if err != nil {
+			return nil, err
+		}
+		if !x.P.ProbablyPrime(20) || x.P.Bit(0) == 0 {
+			return nil, newError(ErrorRuntime, "legendre requires an odd prime modulus")
+		}
+		return Int(big.Jacobi(x.Value, x.P)), nil
+	},
+	Man: "module: (legendre 2 7) - legendre symbol of x modulo an odd prime p, 1 if x is a non-zero square, -1 if not, 0 if x is 0",
+}
+
+var sqrtModExtension = Extension{
+	Name: "sqrt-mod",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		x, err := fieldArgs("sqrt-mod", values...)
+		if err != nil {
+			return nil, err
+		}
+		if !x.P.ProbablyPrime(20) {
+			return nil, newError(ErrorRuntime, "sqrt-mod requires a prime modulus")
+		}
+		if x.P.Cmp(big.NewInt(2)) == 0 {
+			return x, nil // 0 and 1 are their own roots, ModSqrt requires an odd prime
+		}
+		root := new(big.Int).ModSqrt(x.Value, x.P)
+		if root == nil {
+			return nil, newError(ErrorRuntime, "%s has no square root", x)
+		}
+		return NewFp(root, x.P), nil
+	},
+	Man: "module: (sqrt-mod 2 7) - square root of x modulo a prime p as Fp, error if x is not a square",
+}
@@ -0,0 +1,168 @@
+package fp
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+)
+
+// expectNumber : value of code must be Equal to want and of the same Go type, 3 is not 3.0
+func expectNumber(t *testing.T, code string, want Object) {
+	t.Helper()
+	v := mustEval(t, NewStdRuntime(), code)
+	if !Equal(v, want) || fmt.Sprintf("%T", v) != fmt.Sprintf("%T", want) {
+		t.Errorf("%s: got %#v want %#v", code, v, want)
+	}
+}
+
+// bigInt : BigInt of a decimal string
+func bigInt(s string) BigInt {
+	v, _ := new(big.Int).SetString(s, 10)
+	return BigInt{Value: v}
+}
+
+// fp : element x of the field of order p
+func fp(x int64, p int64) Fp {
+	return NewFp(big.NewInt(x), big.NewInt(p))
+}
+
+// TestFloat : literals, promotion of Int to Float and math extensions
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`1.5`, Float(1.5)},
+		{`-2.0`, Float(-2)},
+		{`1e3`, Float(1000)},
+		{`(type 1.0)`, String("Float")},
+		{`(add 1 2.5)`, Float(3.5)},
+		{`(div 7 2)`, Int(3)},
+		{`(div 7.0 2)`, Float(3.5)},
+		{`(mod -7 2)`, Int(-1)},
+		{`(mod 7.5 2)`, Float(1.5)},
+		{`(lt 1 1.5)`, Bool(true)},
+		{`(floor 3.7)`, Int(3)},
+		{`(ceil -3.2)`, Int(-3)},
+		{`(round 2.5)`, Int(3)},
+		{`(sqrt 4)`, Float(2)},
+		{`(pow 2 10)`, Int(1024)},
+		{`(pow 2 -1)`, Float(0.5)},
+		{`(log 100 10)`, Float(2)},
+		{`(str 3.0)`, String("3.0")},
+	}
+	for _, c := range tests {
+		expectNumber(t, c.code, c.want)
+	}
+}
+
+// TestFloatErrors : division by zero and non-numeric operands
+func TestFloatErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(div 1.0 0.0)`, ErrorDivisionByZero},
+		{`(mod 1.5 0)`, ErrorDivisionByZero},
+		{`(add 1.5 "a")`, ErrorTypeMismatch},
+		{`(floor "a")`, ErrorTypeMismatch},
+		{`(floor (exp 1000.0))`, ErrorRuntime},
+		{`(sqrt 1 2)`, ErrorArity},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
+
+// TestBigInt : Int overflows into BigInt and results that fit are Int again
+func TestBigInt(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(add 9223372036854775807 1)`, bigInt("9223372036854775808")},
+		{`(sub -9223372036854775808 1)`, bigInt("-9223372036854775809")},
+		{`(mul 4294967296 4294967296)`, bigInt("18446744073709551616")},
+		{`(mul -1 -9223372036854775808)`, bigInt("9223372036854775808")},
+		{`(div -9223372036854775808 -1)`, bigInt("9223372036854775808")},
+		{`(mod -9223372036854775808 -1)`, Int(0)},
+		{`(sub (add 9223372036854775807 1) 1)`, Int(9223372036854775807)},
+		{`(div 99999999999999999999 99999999999999999999)`, Int(1)},
+		{`(mod 99999999999999999999 7)`, Int(1)},
+		{`(pow 2 100)`, bigInt("1267650600228229401496703205376")},
+		{`(pow -1 99999999999999999999)`, Int(-1)},
+		{`(pow 0 4000000000)`, Int(0)},
+		{`(sign -99999999999999999999)`, Int(-1)},
+		{`(sign 0.5)`, Int(1)},
+		{`99999999999999999999`, bigInt("99999999999999999999")},
+		{`(type 99999999999999999999)`, String("Int")},
+		{`(add 99999999999999999999 0.5)`, Float(1e20)},
+		{`(lt 9223372036854775807 99999999999999999999)`, Bool(true)},
+	}
+	for _, c := range tests {
+		expectNumber(t, c.code, c.want)
+	}
+	expectError(t, NewStdRuntime(), `(div 99999999999999999999 0)`, ErrorDivisionByZero)
+	expectError(t, NewStdRuntime(), `(sign)`, ErrorArity)
+	expectError(t, NewStdRuntime(), `(sign 1 2)`, ErrorArity)
+}
+
+// TestPowTooLarge : results too large to compute are rejected before computing them
+func TestPowTooLarge(t *testing.T) {
+	for _, code := range []string{`(pow 3 4000000000)`, `(pow 2 99999999999999999999)`, `(pow -99999999999999999999 99999999)`} {
+		start := time.Now()
+		expectError(t, NewStdRuntime(), code, ErrorRuntime)
+		if d := time.Since(start); d > time.Second {
+			t.Errorf("%s: took %v", code, d)
+		}
+	}
+	expectNumber(t, `(len (str (pow 2 1000000)))`, Int(301030))
+}
+
+// TestFp : arithmetic modulo p, Int operands are lifted into the field
+func TestFp(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(fp 5 7)`, fp(5, 7)},
+		{`(fp -1 7)`, fp(6, 7)},
+		{`(type (fp 1 7))`, String("Fp")},
+		{`(add (fp 5 7) 4)`, fp(2, 7)},
+		{`(sub 1 (fp 3 7))`, fp(5, 7)},
+		{`(mul (fp 3 7) (fp 5 7))`, fp(1, 7)},
+		{`(div (fp 1 7) (fp 3 7))`, fp(5, 7)},
+		{`(pow (fp 3 7) 6)`, fp(1, 7)},
+		{`(pow (fp 3 7) -1)`, fp(5, 7)},
+		{`(legendre 2 7)`, Int(1)},
+		{`(legendre 3 7)`, Int(-1)},
+		{`(legendre (fp 0 7))`, Int(0)},
+		{`(sqrt-mod 2 7)`, fp(4, 7)},
+		{`(sqrt-mod 1 2)`, fp(1, 2)},
+		{`(sqrt-mod 4 2)`, fp(0, 2)},
+	}
+	for _, c := range tests {
+		expectNumber(t, c.code, c.want)
+	}
+}
+
+// TestFpErrors : no inverse, mixed fields, non-integer operands and bad moduli
+func TestFpErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(div (fp 1 7) (fp 0 7))`, ErrorDivisionByZero},
+		{`(pow (fp 0 7) -1)`, ErrorDivisionByZero},
+		{`(add (fp 1 7) (fp 1 5))`, ErrorTypeMismatch},
+		{`(add (fp 1 7) 1.5)`, ErrorTypeMismatch},
+		{`(mod (fp 1 7) 2)`, ErrorTypeMismatch},
+		{`(fp 1 1)`, ErrorRuntime},
+		{`(sqrt-mod 3 7)`, ErrorRuntime},
+		{`(legendre 2 9)`, ErrorRuntime},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
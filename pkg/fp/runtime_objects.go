@@ -3,6 +3,11 @@ package fp
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // types - TODO implement custom data types like Int, List, Dict
@@ -15,8 +20,14 @@ type Object interface {
 
 func getType(o Object) String {
 	switch o.(type) {
-	case Int:
+	case Int, BigInt:
 		return "Int"
+	case Float:
+		return "Float"
+	case Fp:
+		return "Fp"
+	case Bool:
+		return "Bool"
 	case String:
 		return "String"
 	case Lambda:
@@ -27,6 +38,14 @@ func getType(o Object) String {
 		return "List"
 	case Dict:
 		return "Dict"
+	case Regex:
+		return "Regex"
+	case Macro:
+		return "Macro"
+	case Quoted:
+		return "Expr"
+	case Symbol:
+		return "Symbol"
 	case Wildcard:
 		return "Wildcard"
 	case Unwrap:
@@ -45,14 +64,165 @@ func (i Int) String() string {
 
 func (i Int) MustTypeObject() {}
 
-type Dict map[Object]Object
+// BigInt : Int that does not fit in a machine word, arithmetic on Int is promoted to BigInt on overflow
+type BigInt struct {
+	Value *big.Int
+}
 
-func (d Dict) String() string {
+func (i BigInt) String() string {
+	return i.Value.String()
+}
+
+func (i BigInt) MustTypeObject() {}
+
+// Fp : integer modulo p, an element of the finite field F_p if p is prime
+type Fp struct {
+	Value *big.Int // in [0, p)
+	P     *big.Int
+}
+
+func NewFp(v *big.Int, p *big.Int) Fp {
+	return Fp{
+		Value: new(big.Int).Mod(v, p),
+		P:     p,
+	}
+}
+
+func (x Fp) String() string {
+	return fmt.Sprintf("(fp %s %s)", x.Value.String(), x.P.String())
+}
+
+func (x Fp) MustTypeObject() {}
+
+// Inverse : modular inverse, exists if x and p are coprime
+func (x Fp) Inverse() (Fp, error) {
+	inv := new(big.Int).ModInverse(x.Value, x.P)
+	if inv == nil {
+		return Fp{}, newError(ErrorDivisionByZero, "%s is not invertible", x)
+	}
+	return Fp{Value: inv, P: x.P}, nil
+}
+
+// Pow : exponentiation by squaring, negative exponents use the inverse
+func (x Fp) Pow(e *big.Int) (Fp, error) {
+	if e.Sign() < 0 {
+		inv, err := x.Inverse()
+		if err != nil {
+			return Fp{}, err
+		}
+		return inv.Pow(new(big.Int).Neg(e))
+	}
+	return Fp{Value: new(big.Int).Exp(x.Value, e, x.P), P: x.P}, nil
+}
+
+type Bool bool
+
+func (b Bool) String() string {
+	if b {
+		return "true"
+	}
+	return "false"
+}
+
+func (b Bool) MustTypeObject() {}
+
+type Float float64
+
+func (f Float) String() string {
+	s := strconv.FormatFloat(float64(f), 'g', -1, 64)
+	if !strings.ContainsAny(s, ".eEIN") { // keep 3.0 distinguishable from 3, Inf and NaN are left as is
+		s += ".0"
+	}
+	return s
+}
+
+func (f Float) MustTypeObject() {}
+
+// DictEntry : key value pair of a Dict
+type DictEntry struct {
+	Key   Object
+	Value Object
+}
+
+// Dict : hash map from any Object to Object, keys are compared with Equal
+//
+// a Dict is not modified once it is visible to a program, Set and Delete are for building a new one
+type Dict struct {
+	buckets map[uint64][]DictEntry
+	size    int
+}
+
+func (d Dict) Len() int {
+	return d.size
+}
+
+func (d Dict) Get(key Object) (Object, bool) {
+	for _, entry := range d.buckets[Hash(key)] {
+		if Equal(entry.Key, key) {
+			return entry.Value, true
+		}
+	}
+	return nil, false
+}
+
+func (d *Dict) Set(key Object, value Object) {
+	if d.buckets == nil {
+		d.buckets = make(map[uint64][]DictEntry)
+	}
+	h := Hash(key)
+	bucket := d.buckets[h]
+	for i, entry := range bucket {
+		if Equal(entry.Key, key) {
+			bucket[i].Value = value
+			return
+		}
+	}
+	d.buckets[h] = append(bucket, DictEntry{Key: key, Value: value})
+	d.size++
+}
 
+func (d *Dict) Delete(key Object) bool {
+	h := Hash(key)
+	bucket := d.buckets[h]
+	for i, entry := range bucket {
+		if Equal(entry.Key, key) {
+			d.buckets[h] = slices.Delete(slices.Clone(bucket), i, i+1)
+			if len(d.buckets[h]) == 0 {
+				delete(d.buckets, h)
+			}
+			d.size--
+			return true
+		}
+	}
+	return false
+}
+
+// Clone : shallow copy, keys and values are shared
+func (d Dict) Clone() Dict {
+	buckets := make(map[uint64][]DictEntry, len(d.buckets))
+	for h, bucket := range d.buckets {
+		buckets[h] = slices.Clone(bucket)
+	}
+	return Dict{
+		buckets: buckets,
+		size:    d.size,
+	}
+}
+
+// Entries : key value pairs in no particular order
+func (d Dict) Entries() []DictEntry {
+	entries := make([]DictEntry, 0, d.size)
+	for _, bucket := range d.buckets {
+		entries = append(entries, bucket...)
+	}
+	return entries
+}
+
+func (d Dict) String() string {
 	s := ""
 	s += "{"
-	for k, v := range d {
-		s += fmt.Sprintf("%s -> %s,", k.String(), v.String())
+	for _, entry := range d.sortedEntries() {
+		s += fmt.Sprintf("%s -> %s,", entry.Key.String(), entry.Value.String())
 	}
 	s += "}"
 	return s
@@ -85,16 +255,26 @@ func (s String) String() string {
 func (s String) MustTypeObject() {}
 
 type Lambda struct {
-	Params []String `json:"params,omitempty"`
-	Impl   Expr     `json:"impl,omitempty"`
-	Frame  Frame    `json:"frame,omitempty"`
+	Params   []String `json:"params,omitempty"`
+	Defaults []Expr   `json:"defaults,omitempty"` // default values of the last len(Defaults) params, evaluated at call time
+	Rest     String   `json:"rest,omitempty"`     // param bound to the list of extra arguments, none if empty
+	Impl     Expr     `json:"impl,omitempty"`
+	Env      *Env     `json:"-"` // defining environment, nil at top level
 }
 
 func (l Lambda) String() string {
 	s := "(lambda "
-	for _, param := range l.Params {
+	required := len(l.Params) - len(l.Defaults)
+	for i, param := range l.Params {
+		if i >= required {
+			s += "(" + param.String() + " " + l.Defaults[i-required].String() + ") "
+			continue
+		}
 		s += param.String() + " "
 	}
+	if l.Rest != "" {
+		s += "& " + l.Rest.String() + " "
+	}
 	s += l.Impl.String()
 	s += ")"
 	return s
@@ -105,6 +285,8 @@ func (l Lambda) MustTypeObject() {}
 type Module struct {
 	Name String `json:"name,omitempty"`
 	Exec func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error)
+	// Tail : optional, return the expression in tail position instead of evaluating it, see makeTailModule
+	Tail func(ctx context.Context, r *Runtime, expr LambdaExpr) (Expr, error)
 	Man  string `json:"man,omitempty"`
 }
 
@@ -114,6 +296,48 @@ func (m Module) String() string {
 
 func (m Module) MustTypeObject() {}
 
+// Macro : function from unevaluated argument expressions to the expression evaluated in place of the call
+type Macro struct {
+	Transformer Lambda `json:"transformer"`
+}
+
+func (m Macro) String() string {
+	return "(macro" + strings.TrimPrefix(m.Transformer.String(), "(lambda")
+}
+
+func (m Macro) MustTypeObject() {}
+
+// Regex : compiled regular expression
+type Regex struct {
+	Value *regexp.Regexp
+}
+
+func (r Regex) String() string {
+	return fmt.Sprintf("(re %s)", strconv.Quote(r.Value.String()))
+}
+
+func (r Regex) MustTypeObject() {}
+
+// Quoted : unevaluated expression, see quote
+type Quoted struct {
+	Expr Expr
+}
+
+func (q Quoted) String() string {
+	return fmt.Sprintf("(quote %s)", q.Expr)
+}
+
+func (q Quoted) MustTypeObject() {}
+
+// Symbol : name in an expression converted to a List, see to-list
+type Symbol string
+
+func (s Symbol) String() string {
+	return string(s)
+}
+
+func (s Symbol) MustTypeObject() {}
+
 type List []Object
 
 func (l List) String() string {
@@ -0,0 +1,232 @@
+package fp
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+)
+
+// ModuleSet : named group of modules, a runtime enables or denies a whole set
+type ModuleSet struct {
+	Name    string
+	Modules []Module
+}
+
+func extensions(es ...Extension) []Module {
+	var modules []Module
+	for _, e := range es {
+		modules = append(modules, makeModuleFromExtension(e))
+	}
+	return modules
+}
+
+// CoreModules : variables, functions, control flow and logic
+var CoreModules = ModuleSet{
+	Name: "core",
+	Modules: append([]Module{
+		letModule, delModule, lambdaModule, caseModule, ifModule, andModule, orModule, tailModule,
+	}, extensions(
+		eqExtension, neExtension, notExtension,
+	)...),
+}
+
+// ArithmeticModules : numbers, finite fields and comparisons
+var ArithmeticModules = ModuleSet{
+	Name: "arithmetic",
+	Modules: extensions(
+		addExtension, subExtension, signExtension, mulExtension, divExtension, modExtension,
+		floorExtension, ceilExtension, roundExtension, sqrtExtension, powExtension, expExtension, logExtension,
+		fpExtension, legendreExtension, sqrtModExtension,
+		ltExtension, leExtension, gtExtension, geExtension,
+	),
+}
+
+// ListModules : lists, dicts and higher-order functions
+var ListModules = ModuleSet{
+	Name: "lists",
+	Modules: append([]Module{
+		mapModule, pmapModule, filterModule, reduceModule, foldlModule, foldrModule,
+		anyModule, allModule, findModule, sortModule, groupByModule,
+	}, extensions(
+		listExtension, appendExtension, sliceExtension, peekExtension, lenExtension, rangeExtension,
+		zipExtension, enumerateExtension, reverseExtension, flattenExtension, concatExtension, takeExtension, dropExtension,
+		dictExtension, getExtension, setExtension, hasExtension, removeExtension,
+		keysExtension, valuesExtension, itemsExtension, mergeExtension,
+	)...),
+}
+
+// StringModules : text manipulation, unicode aware, and regular expressions
+var StringModules = ModuleSet{
+	Name: "strings",
+	Modules: append(extensions(
+		strExtension, toStringExtension, parseIntExtension, formatExtension, substrExtension, charsExtension,
+		splitExtension, joinExtension, upperExtension, lowerExtension, trimExtension,
+		containsExtension, startsWithExtension, endsWithExtension, indexOfExtension, replaceExtension,
+	), reModule, reMatchModule, reFindModule, reFindAllModule, reReplaceModule, reSplitModule),
+}
+
+// JSONModules : encode and decode JSON
+var JSONModules = ModuleSet{
+	Name:    "json",
+	Modules: extensions(jsonParseExtension, jsonStringifyExtension),
+}
+
+// MetaModules : code as data, quote and eval expressions, macros
+var MetaModules = ModuleSet{
+	Name: "meta",
+	Modules: append([]Module{
+		quoteModule, quasiquoteModule, unquoteModule, unquoteSplicingModule, evalModule, toListModule,
+		macroModule, defmacroModule, macroexpandModule,
+	}, extensions(toExprExtension, symbolExtension, readExtension, gensymExtension)...),
+}
+
+// IOModules : standard streams and files
+var IOModules = ModuleSet{
+	Name:    "io",
+	Modules: []Module{printModule, printlnModule, eprintModule, readLineModule, readAllModule, importModule},
+}
+
+// IntrospectionModules : inspect and reset the runtime
+var IntrospectionModules = ModuleSet{
+	Name:    "introspection",
+	Modules: append([]Module{stackModule, kaboomModule}, extensions(typeExtension, doomExtension)...),
+}
+
+// TimeModules : clock
+var TimeModules = ModuleSet{
+	Name:    "time",
+	Modules: []Module{timeModule},
+}
+
+// StdModuleSets : module sets of NewStdRuntime
+var StdModuleSets = []ModuleSet{CoreModules, ArithmeticModules, ListModules, StringModules, JSONModules, MetaModules, IOModules, IntrospectionModules, TimeModules}
+
+func (r *Runtime) LoadModuleSet(s ModuleSet) *Runtime {
+	for _, m := range s.Modules {
+		r.LoadModule(m)
+	}
+	return r
+}
+
+type runtimeConfig struct {
+	sets    []ModuleSet
+	enabled map[string]bool
+	stdout  io.Writer
+	stderr  io.Writer
+	stdin   io.Reader
+	clock   func() time.Time
+	limits  Limits
+}
+
+// Option : option of NewRuntime
+type Option func(c *runtimeConfig)
+
+// WithSets : enable only the given module sets
+func WithSets(names ...string) Option {
+	return func(c *runtimeConfig) {
+		c.enabled = make(map[string]bool)
+		for _, name := range names {
+			c.enabled[name] = true
+		}
+	}
+}
+
+// WithoutSets : deny the given module sets
+func WithoutSets(names ...string) Option {
+	return func(c *runtimeConfig) {
+		for _, name := range names {
+			c.enabled[name] = false
+		}
+	}
+}
+
+// WithModuleSet : add a custom module set, enabled unless denied by a later option
+func WithModuleSet(s ModuleSet) Option {
+	return func(c *runtimeConfig) {
+		c.sets = append(c.sets, s)
+		c.enabled[s.Name] = true
+	}
+}
+
+// WithStdout : writer of print and println
+func WithStdout(w io.Writer) Option {
+	return func(c *runtimeConfig) {
+		c.stdout = w
+	}
+}
+
+// WithStderr : writer of eprint
+func WithStderr(w io.Writer) Option {
+	return func(c *runtimeConfig) {
+		c.stderr = w
+	}
+}
+
+// WithStdin : reader of read-line and read-all, buffered once and shared with forks
+func WithStdin(rd io.Reader) Option {
+	return func(c *runtimeConfig) {
+		c.stdin = rd
+	}
+}
+
+// WithClock : clock of time, e.g. a fake clock for reproducible scripts
+func WithClock(now func() time.Time) Option {
+	return func(c *runtimeConfig) {
+		c.clock = now
+	}
+}
+
+// WithLimits : resource limits, see Limits
+func WithLimits(limits Limits) Option {
+	return func(c *runtimeConfig) {
+		c.limits = limits
+	}
+}
+
+// NewRuntime : runtime with the standard module sets, options can deny sets and replace the environment
+//
+// a sandbox for untrusted scripts is e.g. NewRuntime(WithSets("core", "arithmetic", "lists"), WithLimits(...)).
+// an unknown set name given to WithSets or WithoutSets is an error, a misspelled deny must not leave a set enabled
+func NewRuntime(opts ...Option) (*Runtime, error) {
+	c := &runtimeConfig{
+		sets:    append([]ModuleSet(nil), StdModuleSets...),
+		enabled: make(map[string]bool),
+		limits:  DefaultLimits,
+	}
+	for _, s := range StdModuleSets {
+		c.enabled[s.Name] = true
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	known := make(map[string]bool)
+	for _, s := range c.sets {
+		known[s.Name] = true
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.enabled)) {
+		if !known[name] {
+			return nil, fmt.Errorf("unknown module set %s", name)
+		}
+	}
+
+	r := newRuntime()
+	if c.stdout != nil {
+		r.Stdout = c.stdout
+	}
+	if c.stderr != nil {
+		r.Stderr = c.stderr
+	}
+	if c.stdin != nil {
+		r.Stdin = newSharedInput(c.stdin)
+	}
+	r.clock = c.clock
+	r.Limits = c.limits
+	for _, s := range c.sets {
+		if c.enabled[s.Name] {
+			r.LoadModuleSet(s)
+		}
+	}
+	return r, nil
+}
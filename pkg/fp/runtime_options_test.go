@@ -0,0 +1,52 @@
+package fp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOptions(t *testing.T) {
+	sandbox, err := NewRuntime(WithSets("core", "arithmetic", "lists"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"print", "import", "kaboom", "stack", "time", "quote"} {
+		expectError(t, sandbox, "("+name+")", ErrorUnboundName)
+	}
+	expect(t, sandbox, `(len (map (range 1 3) (lambda x (mul x x))))`, Int(3))
+
+	out := &strings.Builder{}
+	r, err := NewRuntime(
+		WithoutSets("introspection"),
+		WithStdout(out),
+		WithClock(func() time.Time { return time.Unix(0, 42) }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expect(t, r, `(time)`, Int(42))
+	expect(t, r, `(print "hello" 1)`, Int(2))
+	if out.String() != "hello 1 \n" {
+		t.Errorf("print wrote %q", out.String())
+	}
+	expectError(t, r, `(kaboom)`, ErrorUnboundName)
+}
+
+func TestOptionsUnknownSet(t *testing.T) {
+	custom := ModuleSet{Name: "custom"}
+	for _, opts := range [][]Option{
+		{WithSets("nope")},
+		{WithoutSets("introspectoin")},
+		{WithSets("core"), WithoutSets("nope")},
+	} {
+		if r, err := NewRuntime(opts...); err == nil || r != nil {
+			t.Errorf("unknown module set: got runtime %v, error %v", r, err)
+		} else if !strings.Contains(err.Error(), "unknown module set") {
+			t.Errorf("unexpected error %v", err)
+		}
+	}
+	if _, err := NewRuntime(WithoutSets("custom"), WithModuleSet(custom)); err != nil {
+		t.Errorf("denying a set added by a later option: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package fp
+
+import (
+	"context"
+	"runtime"
+	"sync"
+)
+
+var pmapModule = Module{
+	Name: "pmap",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		values, err := r.stepArgs(ctx, expr)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 2 && len(values) != 3 {
+			return nil, newError(ErrorArity, "pmap requires 2 or 3 arguments")
+		}
+		l, err := listArg("pmap", values[0])
+		if err != nil {
+			return nil, err
+		}
+		f := values[1]
+		workers := runtime.NumCPU()
+		if len(values) == 3 {
+			n, err := intArg("pmap", values[2])
+			if err != nil {
+				return nil, err
+			}
+			if n < 1 {
+				return nil, newError(ErrorRuntime, "pmap requires at least 1 worker")
+			}
+			workers = int(n)
+		}
+		workers = min(workers, len(l))
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		outputs := make(List, len(l))
+		var firstErr error
+		var once sync.Once
+		jobs := make(chan int)
+		wg := &sync.WaitGroup{}
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				child := r.Fork()
+				defer child.Release()
+				for i := range jobs {
+					o, err := child.call(ctx, expr, f, l[i])
+					if err != nil {
+						// keep the first error, the others are interrupts caused by cancel
+						once.Do(func() {
+							firstErr = err
+							cancel()
+						})
+						continue
+					}
+					outputs[i] = o
+				}
+			}()
+		}
+	feed:
+		for i := range l {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				break feed
+			}
+		}
+		close(jobs)
+		wg.Wait()
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		if ctx.Err() != nil {
+			return nil, InterruptError
+		}
+		return outputs, nil
+	},
+	Man: "module: (pmap l (lambda y (add 1 y)) 4) - map in parallel with at most 4 workers (default number of cpus), the function must not modify outer variables",
+}
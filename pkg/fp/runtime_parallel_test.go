@@ -0,0 +1,107 @@
+package fp
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestForkConcurrent : forks of a preloaded runtime evaluate concurrently without seeing each other, run with -race
+func TestForkConcurrent(t *testing.T) {
+	r := NewStdRuntime()
+	r.SetGlobal("base", Int(100))
+	mustEval(t, r, `(let inc (lambda x (add x 1)))`)
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			child := r.Fork()
+			defer child.Release()
+			code := fmt.Sprintf("(let base (add base %d)) (let inc 0) (peek (pmap (range 0 10) (lambda x (add x base))) 10)", i)
+			v, err := eval(child, code)
+			if err != nil || !Equal(v, Int(100+i+9)) {
+				t.Errorf("%s: got %v, %v", code, v, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// forks must not leak into the parent
+	expect(t, r, `(inc base)`, Int(101))
+	if len(r.shared) != 0 {
+		t.Errorf("%d environments still shared after every fork is released", len(r.shared))
+	}
+}
+
+// TestForkCopyOnWrite : the parent and a live fork write their own copies of shared frames
+func TestForkCopyOnWrite(t *testing.T) {
+	r := NewStdRuntime()
+	mustEval(t, r, `(let x 1)`)
+	child := r.Fork()
+	mustEval(t, r, `(let x 2)`)
+	mustEval(t, child, `(let y 3)`)
+	expect(t, child, `x`, Int(1))
+	expectError(t, r, `y`, ErrorUnboundName)
+	child.Release()
+	child.Release()
+	expect(t, r, `x`, Int(2))
+}
+
+// TestPmapKeepsClosures : after pmap, let writes the frame of the call in place, closures created before see it
+func TestPmapKeepsClosures(t *testing.T) {
+	r := NewStdRuntime()
+	mustEval(t, r, `(let g (lambda n (tail
+		(let h (lambda z y))
+		(pmap (list 1 2 3) (lambda x (mul x n)))
+		(let y n)
+		(h 0)
+	)))`)
+	expect(t, r, `(g 5)`, Int(5))
+}
+
+// TestPmap : same result as map in order, errors of any element are returned
+func TestPmap(t *testing.T) {
+	r := NewStdRuntime()
+	for _, c := range []struct {
+		code string
+		want Object
+	}{
+		{`(pmap (range 1 100) (lambda x (mul x x)) 4)`, mustEval(t, r, `(map (range 1 100) (lambda x (mul x x)))`)},
+		{`(pmap (list) (lambda x x))`, List{}},
+		{`(pmap (list 1 2) (lambda x (pmap (list x x) (lambda y (add x y)))))`, List{ints(2, 2), ints(4, 4)}},
+	} {
+		expect(t, r, c.code, c.want)
+	}
+	expectError(t, r, `(pmap (range 1 100) (lambda x (div 1 (sub x 50))))`, ErrorDivisionByZero)
+	expectError(t, r, `(pmap (list 1) (lambda x x) 0)`, ErrorRuntime)
+}
+
+// TestPmapParallel : the workers run at the same time, each call waits until all of them have started
+func TestPmapParallel(t *testing.T) {
+	const workers = 4
+	var mu sync.Mutex
+	started := 0
+	all := make(chan struct{})
+	r := NewStdRuntime()
+	r.LoadExtension(Extension{
+		Name: "meet",
+		Exec: func(ctx context.Context, values ...Object) (Object, error) {
+			mu.Lock()
+			if started++; started == workers {
+				close(all)
+			}
+			mu.Unlock()
+			select {
+			case <-all:
+				return Bool(true), nil
+			case <-time.After(5 * time.Second):
+				return Bool(false), nil
+			}
+		},
+	})
+	expect(t, r, fmt.Sprintf(`(pmap (range 1 %d) (lambda x (meet)) %d)`, workers, workers), List{Bool(true), Bool(true), Bool(true), Bool(true)})
+}
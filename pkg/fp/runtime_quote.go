@@ -0,0 +1,229 @@
+package fp
+
+import (
+	"context"
+)
+
+// nameArg : name of a variable in a module argument
+func nameArg(module String, expr Expr) (String, error) {
+	e, ok := expr.(NameExpr)
+	if !ok {
+		return "", newError(ErrorTypeMismatch, "%s requires a name but got %s", module, expr)
+	}
+	return String(e.Name), nil
+}
+
+// toObject : List of Symbols representing an expression, literals are converted to their values
+func (r *Runtime) toObject(expr Expr) Object {
+	switch e := expr.(type) {
+	case NameExpr:
+		return Symbol(e.Name)
+	case LambdaExpr:
+		l := List{Symbol(e.Name.Name)}
+		for _, arg := range e.Args {
+			l = append(l, r.toObject(arg))
+		}
+		return l
+	case ValueExpr:
+		return e.Value
+	default:
+		return nil
+	}
+}
+
+// toExpr : expression represented by an object, inverse of toObject
+//
+// a Quoted is its expression, a Symbol is a name, a List starting with a Symbol is a call
+// and any other value evaluates to itself
+func toExpr(o Object, span Span) Expr {
+	switch o := o.(type) {
+	case Quoted:
+		return o.Expr
+	case Symbol:
+		if v, ok, err := parseLiteral(string(o)); ok && err == nil {
+			return ValueExpr{Value: v, Span: span} // (symbol "1") is the literal 1, as in source
+		}
+		return NameExpr{Name: string(o), Span: span}
+	case List:
+		if len(o) == 0 {
+			return ValueExpr{Value: o, Span: span}
+		}
+		head, ok := o[0].(Symbol)
+		if !ok {
+			return ValueExpr{Value: o, Span: span}
+		}
+		e := LambdaExpr{Name: NameExpr{Name: string(head), Span: span}, Span: span}
+		for _, arg := range o[1:] {
+			e.Args = append(e.Args, toExpr(arg, span))
+		}
+		return e
+	default:
+		return ValueExpr{Value: o, Span: span}
+	}
+}
+
+// quasiquote : copy of expr where unquote is replaced by its value and unquote-splicing by the elements of its value
+func (r *Runtime) quasiquote(ctx context.Context, expr Expr) (Expr, error) {
+	e, ok := expr.(LambdaExpr)
+	if !ok {
+		return expr, nil
+	}
+	switch e.Name.Name {
+	case "unquote":
+		if len(e.Args) != 1 {
+			return nil, newError(ErrorArity, "unquote requires 1 argument")
+		}
+		v, err := r.Step(ctx, e.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return toExpr(v, e.Span), nil
+	case "unquote-splicing":
+		return nil, newError(ErrorRuntime, "unquote-splicing must be an argument of a call")
+	}
+	out := LambdaExpr{Name: e.Name, Span: e.Span}
+	for _, arg := range e.Args {
+		if a, ok := arg.(LambdaExpr); ok && a.Name.Name == "unquote-splicing" {
+			if len(a.Args) != 1 {
+				return nil, newError(ErrorArity, "unquote-splicing requires 1 argument")
+			}
+			v, err := r.Step(ctx, a.Args[0])
+			if err != nil {
+				return nil, err
+			}
+			l, ok := v.(List)
+			if !ok {
+				return nil, newError(ErrorTypeMismatch, "unquote-splicing requires a list but got %s", getType(v))
+			}
+			for _, elem := range l {
+				out.Args = append(out.Args, toExpr(elem, a.Span))
+			}
+			continue
+		}
+		v, err := r.quasiquote(ctx, arg)
+		if err != nil {
+			return nil, err
+		}
+		out.Args = append(out.Args, v)
+	}
+	return out, nil
+}
+
+var quoteModule = Module{
+	Name: "quote",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) != 1 {
+			return nil, newError(ErrorArity, "quote requires 1 argument")
+		}
+		return Quoted{Expr: expr.Args[0]}, nil
+	},
+	Man: "module: (quote (add 1 x)) - the expression (add 1 x) without evaluating it",
+}
+
+var quasiquoteModule = Module{
+	Name: "quasiquote",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		if len(expr.Args) != 1 {
+			return nil, newError(ErrorArity, "quasiquote requires 1 argument")
+		}
+		e, err := r.quasiquote(ctx, expr.Args[0])
+		if err != nil {
+			return nil, err
+		}
+		return Quoted{Expr: e}, nil
+	},
+	Man: "module: (quasiquote (add (unquote x) (unquote-splicing l))) - quote, except for the value of x and the elements of l",
+}
+
+// makeUnquoteModule : unquote and unquote-splicing are only meaningful inside quasiquote
+func makeUnquoteModule(name String, man string) Module {
+	return Module{
+		Name: name,
+		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+			return nil, newError(ErrorRuntime, "%s outside of quasiquote", name)
+		},
+		Man: man,
+	}
+}
+
+var unquoteModule = makeUnquoteModule("unquote", "module: (unquote x) - inside quasiquote, the value of x")
+
+var unquoteSplicingModule = makeUnquoteModule("unquote-splicing", "module: (unquote-splicing l) - inside quasiquote, the elements of l as arguments")
+
+var evalModule = makeTailModule("eval", func(ctx context.Context, r *Runtime, expr LambdaExpr) (Expr, error) {
+	if len(expr.Args) != 1 {
+		return nil, newError(ErrorArity, "eval requires 1 argument")
+	}
+	v, err := r.Step(ctx, expr.Args[0])
+	if err != nil {
+		return nil, err
+	}
+	return toExpr(v, expr.Span), nil
+}, "module: (eval (list (symbol \"add\") 1 2)) - evaluate an expression or a list representing one, 3")
+
+var toListModule = Module{
+	Name: "to-list",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		values, err := r.stepArgs(ctx, expr)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "to-list requires 1 argument")
+		}
+		q, ok := values[0].(Quoted)
+		if !ok {
+			return nil, newError(ErrorTypeMismatch, "to-list requires an expression but got %s", getType(values[0]))
+		}
+		return r.toObject(q.Expr), nil
+	},
+	Man: "module: (to-list (quote (add 1 x))) - nested list of symbols and values, [add,1,x,]",
+}
+
+var toExprExtension = Extension{
+	Name: "to-expr",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "to-expr requires 1 argument")
+		}
+		return Quoted{Expr: toExpr(values[0], Span{})}, nil
+	},
+	Man: "module: (to-expr l) - expression represented by a list of symbols, inverse of to-list",
+}
+
+var symbolExtension = Extension{
+	Name: "symbol",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "symbol requires 1 argument")
+		}
+		s, err := stringArg("symbol", values[0])
+		if err != nil {
+			return nil, err
+		}
+		return Symbol(s), nil
+	},
+	Man: "module: (symbol \"x\") - symbol x, a name in a list representing an expression",
+}
+
+var readExtension = Extension{
+	Name: "read",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "read requires 1 argument")
+		}
+		s, err := stringArg("read", values[0])
+		if err != nil {
+			return nil, err
+		}
+		e, rest, err := parseSingle(Tokenize(string(s)))
+		if err != nil {
+			return nil, newError(ErrorRuntime, "read: %w", err)
+		}
+		if len(rest) > 0 {
+			return nil, newError(ErrorRuntime, "read: unexpected %s after expression", quoteToken(rest[0]))
+		}
+		return Quoted{Expr: e}, nil
+	},
+	Man: "module: (read \"(add 1 2)\") - parse a string into an expression without evaluating it",
+}
@@ -0,0 +1,51 @@
+package fp
+
+import (
+	"testing"
+)
+
+// TestQuote : expressions round-trip through lists, quasiquote substitutes values, eval runs built code
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(to-list (quote (add 1 x "s")))`, List{Symbol("add"), Int(1), Symbol("x"), String("s")}},
+		{`(eq (to-expr (to-list (quote (add 1 (mul x 2))))) (quote (add 1 (mul x 2))))`, Bool(true)},
+		{`(type (quote x))`, String("Expr")},
+		{`(let x 5) (eval (quote (add x 1)))`, Int(6)},
+		{`(let x 5) (let l (list 1 2 3)) (quasiquote (add (unquote x) (unquote-splicing l)))`, Quoted{Expr: LambdaExpr{
+			Name: NameExpr{Name: "add"},
+			Args: []Expr{ValueExpr{Value: Int(5)}, ValueExpr{Value: Int(1)}, ValueExpr{Value: Int(2)}, ValueExpr{Value: Int(3)}},
+		}}},
+		{`(let x 5) (let l (list 1 2 3)) (eval (quasiquote (add (unquote x) (unquote-splicing l))))`, Int(11)},
+		{`(let l (list 1 2 3)) (eval (quasiquote (len (unquote l))))`, Int(3)},
+		{`(eval (list (symbol "mul") 6 7))`, Int(42)},
+		{`(eval (read "(str \"a\" (add 1 2))"))`, String("a3")},
+		{`(let x 5) (let f (eval (read "(lambda y (add x y))"))) (f 2)`, Int(7)},
+		{`(let loop (lambda i (if (eq i 0) 0 (eval (quasiquote (loop (unquote (sub i 1)))))))) (loop 100000)`, Int(0)},
+	}
+	for _, c := range tests {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+// TestQuoteErrors : malformed code, unquote outside of quasiquote and non-name variables
+func TestQuoteErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(read "(add 1")`, ErrorRuntime},
+		{`(read "1 2")`, ErrorRuntime},
+		{`(unquote x)`, ErrorRuntime},
+		{`(let l (list 1)) (quasiquote (unquote-splicing l))`, ErrorRuntime},
+		{`(quasiquote (f (unquote-splicing 1)))`, ErrorTypeMismatch},
+		{`(let (add) 1)`, ErrorTypeMismatch},
+		{`(to-list 1)`, ErrorTypeMismatch},
+		{`(quote 1 2)`, ErrorArity},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
@@ -0,0 +1,153 @@
+package fp
+
+import (
+	"context"
+	"regexp"
+	"sync"
+)
+
+// regexpCacheSize : number of patterns kept compiled, the cache is emptied when it is full
+const regexpCacheSize = 256
+
+// regexpCache : compiled patterns by source, shared by a Runtime and its forks
+type regexpCache struct {
+	mu       sync.Mutex
+	patterns map[string]*regexp.Regexp
+}
+
+// compile : compiled pattern, from the cache if it was compiled before
+func (r *Runtime) compile(pattern string) (*regexp.Regexp, error) {
+	if r.regexps == nil {
+		r.regexps = &regexpCache{}
+	}
+	c := r.regexps
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if re, ok := c.patterns[pattern]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, newError(ErrorRuntime, "invalid regex %q: %v", pattern, err)
+	}
+	if c.patterns == nil || len(c.patterns) >= regexpCacheSize {
+		c.patterns = make(map[string]*regexp.Regexp)
+	}
+	c.patterns[pattern] = re
+	return re, nil
+}
+
+// regexArg : a Regex or a String compiled as a pattern
+func (r *Runtime) regexArg(name String, o Object) (*regexp.Regexp, error) {
+	switch o := o.(type) {
+	case Regex:
+		return o.Value, nil
+	case String:
+		return r.compile(string(o))
+	default:
+		return nil, newError(ErrorTypeMismatch, "%s requires a regex or a string but got %s", name, getType(o))
+	}
+}
+
+// makeRegexModule : module whose first argument is a pattern and second argument is a string
+func makeRegexModule(name String, minArgs int, maxArgs int, exec func(re *regexp.Regexp, s string, values []Object) (Object, error), man string) Module {
+	return Module{
+		Name: name,
+		Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+			values, err := r.stepArgs(ctx, expr)
+			if err != nil {
+				return nil, err
+			}
+			if len(values) < minArgs || len(values) > maxArgs {
+				return nil, newError(ErrorArity, "%s requires %d to %d arguments", name, minArgs, maxArgs)
+			}
+			re, err := r.regexArg(name, values[0])
+			if err != nil {
+				return nil, err
+			}
+			s, err := stringArg(name, values[1])
+			if err != nil {
+				return nil, err
+			}
+			return exec(re, string(s), values[2:])
+		},
+		Man: man,
+	}
+}
+
+// groups : list of the match and its capture groups, "" for groups that did not participate
+func groups(match []string) List {
+	var l List
+	for _, g := range match {
+		l = append(l, String(g))
+	}
+	return l
+}
+
+// countArg : optional maximum number of results, -1 means all
+func countArg(name String, values []Object) (int, error) {
+	if len(values) == 0 {
+		return -1, nil
+	}
+	n, err := intArg(name, values[0])
+	return int(n), err
+}
+
+var reModule = Module{
+	Name: "re",
+	Exec: func(ctx context.Context, r *Runtime, expr LambdaExpr) (Object, error) {
+		values, err := r.stepArgs(ctx, expr)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "re requires 1 argument")
+		}
+		re, err := r.regexArg("re", values[0])
+		if err != nil {
+			return nil, err
+		}
+		return Regex{Value: re}, nil
+	},
+	Man: "module: (re \"[0-9]+\") - compile a regular expression (Go syntax), functions re-* take a Regex or a pattern string",
+}
+
+var reMatchModule = makeRegexModule("re-match", 2, 2, func(re *regexp.Regexp, s string, values []Object) (Object, error) {
+	return Bool(re.MatchString(s)), nil
+}, "module: (re-match (re \"^a+$\") s) - true if the regex matches somewhere in s, anchor with ^ and $ to match all of s")
+
+var reFindModule = makeRegexModule("re-find", 2, 2, func(re *regexp.Regexp, s string, values []Object) (Object, error) {
+	return groups(re.FindStringSubmatch(s)), nil
+}, "module: (re-find \"(\\\\w+)@(\\\\w+)\" s) - list of the first match and its capture groups, empty list if not found")
+
+var reFindAllModule = makeRegexModule("re-find-all", 2, 3, func(re *regexp.Regexp, s string, values []Object) (Object, error) {
+	n, err := countArg("re-find-all", values)
+	if err != nil {
+		return nil, err
+	}
+	var l List
+	for _, match := range re.FindAllStringSubmatch(s, n) {
+		l = append(l, groups(match))
+	}
+	return l, nil
+}, "module: (re-find-all \"[0-9]+\" s 10) - list of the first 10 matches as lists of capture groups, all matches if 10 is omitted")
+
+var reReplaceModule = makeRegexModule("re-replace", 3, 3, func(re *regexp.Regexp, s string, values []Object) (Object, error) {
+	repl, err := stringArg("re-replace", values[0])
+	if err != nil {
+		return nil, err
+	}
+	return String(re.ReplaceAllString(s, string(repl))), nil
+}, "module: (re-replace \"(\\\\w+)@\" s \"${1} at \") - replace all matches, $1 or ${name} refer to capture groups")
+
+var reSplitModule = makeRegexModule("re-split", 2, 3, func(re *regexp.Regexp, s string, values []Object) (Object, error) {
+	n, err := countArg("re-split", values)
+	if err != nil {
+		return nil, err
+	}
+	var l List
+	for _, part := range re.Split(s, n) {
+		l = append(l, String(part))
+	}
+	return l, nil
+}, "module: (re-split \"\\\\s*,\\\\s*\" s) - list of substrings between matches, at most 3 substrings with (re-split p s 3)")
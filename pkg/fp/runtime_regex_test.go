@@ -0,0 +1,56 @@
+package fp
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestRegex : patterns are Regex or strings, matches are lists of capture groups
+func TestRegex(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(type (re "[0-9]+"))`, String("Regex")},
+		{`(re-match (re "^a+$") "aaa")`, Bool(true)},
+		{`(re-match "^a+$" "aab")`, Bool(false)},
+		{`(re-find "(\\w+)@(\\w+)" "mail bob@home now")`, List{String("bob@home"), String("bob"), String("home")}},
+		{`(re-find "x" "abc")`, List(nil)},
+		{`(re-find-all "[0-9]+" "a1b22c333" 2)`, List{List{String("1")}, List{String("22")}}},
+		{`(map (re-find-all "(\\pL+)=(\\d+)" "a=1 é=2") (lambda m (peek m 2)))`, List{String("a"), String("é")}},
+		{`(re-replace "(\\w+)@" "bob@home" "${1} at ")`, String("bob at home")},
+		{`(re-split "\\s*,\\s*" "a , b,c")`, List{String("a"), String("b"), String("c")}},
+		{`(re-split "," "a,b,c" 2)`, List{String("a"), String("b,c")}},
+		{`(eq (re "a+") (re "a+"))`, Bool(true)},
+	}
+	for _, c := range tests {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+// TestRegexErrors : invalid patterns and arguments of the wrong type
+func TestRegexErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(re "(")`, ErrorRuntime},
+		{`(re-match 1 "a")`, ErrorTypeMismatch},
+		{`(re-match "a" 1)`, ErrorTypeMismatch},
+		{`(re-match "a")`, ErrorArity},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
+
+// TestRegexCache : a full cache is emptied and patterns are compiled again
+func TestRegexCache(t *testing.T) {
+	r := NewStdRuntime()
+	for i := 0; i < 2*regexpCacheSize; i++ {
+		expect(t, r, fmt.Sprintf(`(re-match "^a{%d}$" "b")`, i), Bool(false))
+	}
+	if n := len(r.regexps.patterns); n > regexpCacheSize {
+		t.Errorf("got %d cached patterns want at most %d", n, regexpCacheSize)
+	}
+}
@@ -0,0 +1,331 @@
+package fp
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode/utf8"
+)
+
+func stringArg(name String, o Object) (String, error) {
+	s, ok := o.(String)
+	if !ok {
+		return "", newError(ErrorTypeMismatch, "%s requires a string but got %s", name, getType(o))
+	}
+	return s, nil
+}
+
+// stringArgs : all values must be strings
+func stringArgs(name String, values []Object) ([]string, error) {
+	var strs []string
+	for _, v := range values {
+		s, err := stringArg(name, v)
+		if err != nil {
+			return nil, err
+		}
+		strs = append(strs, string(s))
+	}
+	return strs, nil
+}
+
+// makeStringExtension : extension of a fixed number of string arguments
+func makeStringExtension(name String, arity int, exec func(strs []string) (Object, error), man string) Extension {
+	return Extension{
+		Name: name,
+		Exec: func(ctx context.Context, values ...Object) (Object, error) {
+			if len(values) != arity {
+				return nil, newError(ErrorArity, "%s requires %d arguments", name, arity)
+			}
+			strs, err := stringArgs(name, values)
+			if err != nil {
+				return nil, err
+			}
+			return exec(strs)
+		},
+		Man: man,
+	}
+}
+
+var strExtension = Extension{
+	Name: "str",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		var sb strings.Builder
+		for _, v := range values {
+			sb.WriteString(fmt.Sprintf("%v", v))
+		}
+		return String(sb.String()), nil
+	},
+	Man: "module: (str \"x = \" 1) - concatenate values converted to strings",
+}
+
+var toStringExtension = Extension{
+	Name: "to-string",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 {
+			return nil, newError(ErrorArity, "to-string requires 1 argument")
+		}
+		return String(fmt.Sprintf("%v", values[0])), nil
+	},
+	Man: "module: (to-string 12) - string representation of a value, \"12\"",
+}
+
+var parseIntExtension = Extension{
+	Name: "parse-int",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, newError(ErrorArity, "parse-int requires 1 or 2 arguments")
+		}
+		s, err := stringArg("parse-int", values[0])
+		if err != nil {
+			return nil, err
+		}
+		base := Int(10)
+		if len(values) == 2 {
+			if base, err = intArg("parse-int", values[1]); err != nil {
+				return nil, err
+			}
+			if base < 2 || base > 62 {
+				return nil, newError(ErrorRuntime, "parse-int base must be between 2 and 62")
+			}
+		}
+		v, ok := new(big.Int).SetString(string(s), int(base))
+		if !ok {
+			return nil, newError(ErrorRuntime, "parse-int cannot parse %q in base %d", string(s), base)
+		}
+		return normalizeBig(v), nil
+	},
+	Man: "module: (parse-int \"ff\" 16) - parse an integer, base 10 if omitted",
+}
+
+// formatArg : Go value of an object for fmt verbs
+func formatArg(o Object) any {
+	switch o := o.(type) {
+	case Int:
+		return int64(o)
+	case BigInt:
+		return o.Value
+	case Float:
+		return float64(o)
+	case Bool:
+		return bool(o)
+	case String:
+		return string(o)
+	default:
+		return fmt.Sprintf("%v", o)
+	}
+}
+
+// maxFormatWidth : largest width or precision of a format verb, fmt pads before the string size is checked
+const maxFormatWidth = 1 << 16
+
+// formatPadding : sum of the widths and precisions of the verbs of a printf format, or an error if one is too large
+func formatPadding(format string) (int, error) {
+	total := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		n := 0
+		for i++; i < len(format); i++ {
+			c := format[i]
+			if '0' <= c && c <= '9' {
+				if n = n*10 + int(c-'0'); n > maxFormatWidth {
+					return 0, newError(ErrorRuntime, "format width or precision larger than %d", maxFormatWidth)
+				}
+				continue
+			}
+			total, n = total+n, 0
+			if c != '.' && c != '[' && c != ']' && !strings.ContainsRune("+-# ", rune(c)) {
+				break
+			}
+		}
+		total += n
+	}
+	return total, nil
+}
+
+var formatExtension = Extension{
+	Name: "format",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) < 1 {
+			return nil, newError(ErrorArity, "format requires at least 1 argument")
+		}
+		format, err := stringArg("format", values[0])
+		if err != nil {
+			return nil, err
+		}
+		padding, err := formatPadding(string(format))
+		if err != nil {
+			return nil, err
+		}
+		if err := LimitsFromContext(ctx).checkSize(padding); err != nil {
+			return nil, err
+		}
+		var args []any
+		for _, v := range values[1:] {
+			args = append(args, formatArg(v))
+		}
+		return String(fmt.Sprintf(string(format), args...)), nil
+	},
+	Man: "module: (format \"%s is %d years old, %.2f\" name 30 1.5) - printf-style formatting",
+}
+
+// runeIndex : 1-indexed position of a rune in s, from a 0-indexed byte offset
+func runeIndex(s string, offset int) Int {
+	return Int(utf8.RuneCountInString(s[:offset]) + 1)
+}
+
+var substrExtension = Extension{
+	Name: "substr",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 2 && len(values) != 3 {
+			return nil, newError(ErrorArity, "substr requires 2 or 3 arguments")
+		}
+		s, err := stringArg("substr", values[0])
+		if err != nil {
+			return nil, err
+		}
+		runes := []rune(string(s))
+		beg, err := intArg("substr", values[1])
+		if err != nil {
+			return nil, err
+		}
+		end := Int(len(runes))
+		if len(values) == 3 {
+			if end, err = intArg("substr", values[2]); err != nil {
+				return nil, err
+			}
+		}
+		if beg < 1 || end > Int(len(runes)) || beg > end+1 {
+			return nil, newError(ErrorRuntime, "substr [%d, %d] out of range of string of length %d", beg, end, len(runes))
+		}
+		return String(runes[beg-1 : end]), nil
+	},
+	Man: "module: (substr s 2 3) - characters 2 to 3 of s (1-indexing, closed interval), to the end if 3 is omitted",
+}
+
+var charsExtension = makeStringExtension("chars", 1, func(strs []string) (Object, error) {
+	var l List
+	for _, c := range strs[0] {
+		l = append(l, String(c))
+	}
+	return l, nil
+}, "module: (chars \"héllo\") - list of characters")
+
+var splitExtension = Extension{
+	Name: "split",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, newError(ErrorArity, "split requires 1 or 2 arguments")
+		}
+		strs, err := stringArgs("split", values)
+		if err != nil {
+			return nil, err
+		}
+		var parts []string
+		if len(strs) == 1 {
+			parts = strings.Fields(strs[0])
+		} else {
+			parts = strings.Split(strs[0], strs[1])
+		}
+		var l List
+		for _, part := range parts {
+			l = append(l, String(part))
+		}
+		return l, nil
+	},
+	Man: "module: (split \"a,b\" \",\") - list of substrings separated by \",\", separated by whitespace if omitted",
+}
+
+var joinExtension = Extension{
+	Name: "join",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, newError(ErrorArity, "join requires 1 or 2 arguments")
+		}
+		l, err := listArg("join", values[0])
+		if err != nil {
+			return nil, err
+		}
+		strs, err := stringArgs("join", l)
+		if err != nil {
+			return nil, err
+		}
+		sep := String("")
+		if len(values) == 2 {
+			if sep, err = stringArg("join", values[1]); err != nil {
+				return nil, err
+			}
+		}
+		return String(strings.Join(strs, string(sep))), nil
+	},
+	Man: "module: (join l \", \") - concatenate a list of strings separated by \", \"",
+}
+
+var upperExtension = makeStringExtension("upper", 1, func(strs []string) (Object, error) {
+	return String(strings.ToUpper(strs[0])), nil
+}, "module: (upper s) - s in upper case")
+
+var lowerExtension = makeStringExtension("lower", 1, func(strs []string) (Object, error) {
+	return String(strings.ToLower(strs[0])), nil
+}, "module: (lower s) - s in lower case")
+
+var trimExtension = Extension{
+	Name: "trim",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 1 && len(values) != 2 {
+			return nil, newError(ErrorArity, "trim requires 1 or 2 arguments")
+		}
+		strs, err := stringArgs("trim", values)
+		if err != nil {
+			return nil, err
+		}
+		if len(strs) == 1 {
+			return String(strings.TrimSpace(strs[0])), nil
+		}
+		return String(strings.Trim(strs[0], strs[1])), nil
+	},
+	Man: "module: (trim s \"-\") - s without leading and trailing characters in \"-\", whitespace if omitted",
+}
+
+var containsExtension = makeStringExtension("contains", 2, func(strs []string) (Object, error) {
+	return Bool(strings.Contains(strs[0], strs[1])), nil
+}, "module: (contains s \"ab\") - true if \"ab\" is in s")
+
+var startsWithExtension = makeStringExtension("starts-with", 2, func(strs []string) (Object, error) {
+	return Bool(strings.HasPrefix(strs[0], strs[1])), nil
+}, "module: (starts-with s \"ab\") - true if s starts with \"ab\"")
+
+var endsWithExtension = makeStringExtension("ends-with", 2, func(strs []string) (Object, error) {
+	return Bool(strings.HasSuffix(strs[0], strs[1])), nil
+}, "module: (ends-with s \"ab\") - true if s ends with \"ab\"")
+
+var indexOfExtension = makeStringExtension("index-of", 2, func(strs []string) (Object, error) {
+	i := strings.Index(strs[0], strs[1])
+	if i < 0 {
+		return Int(0), nil
+	}
+	return runeIndex(strs[0], i), nil
+}, "module: (index-of s \"ab\") - position of the first \"ab\" in s (1-indexing), 0 if not found")
+
+var replaceExtension = Extension{
+	Name: "replace",
+	Exec: func(ctx context.Context, values ...Object) (Object, error) {
+		if len(values) != 3 && len(values) != 4 {
+			return nil, newError(ErrorArity, "replace requires 3 or 4 arguments")
+		}
+		strs, err := stringArgs("replace", values[:3])
+		if err != nil {
+			return nil, err
+		}
+		n := Int(-1)
+		if len(values) == 4 {
+			if n, err = intArg("replace", values[3]); err != nil {
+				return nil, err
+			}
+		}
+		return String(strings.Replace(strs[0], strs[1], strs[2], int(n))), nil
+	},
+	Man: "module: (replace s \"a\" \"b\" 2) - replace the first 2 \"a\" in s by \"b\", all of them if 2 is omitted",
+}
@@ -0,0 +1,61 @@
+package fp
+
+import (
+	"testing"
+)
+
+// TestStrings : string functions count characters, not bytes
+func TestStrings(t *testing.T) {
+	tests := []struct {
+		code string
+		want Object
+	}{
+		{`(len "héllo")`, Int(5)},
+		{`(substr "héllo" 2 3)`, String("él")},
+		{`(substr "héllo" 4)`, String("lo")},
+		{`(index-of "日本語" "語")`, Int(3)},
+		{`(index-of "abc" "z")`, Int(0)},
+		{`(join (reverse (chars "añb")) "")`, String("bña")},
+		{`(upper "héllo")`, String("HÉLLO")},
+		{`(lower "ÀB")`, String("àb")},
+		{`(str "x = " 1 " " 2.0)`, String("x = 1 2.0")},
+		{`(to-string (list 1 "a"))`, String("[1,a,]")},
+		{`(format "%s=%d" "x" (parse-int "-42"))`, String("x=-42")},
+		{`(format "%.2f" 1.5)`, String("1.50")},
+		{`(format "%5d|%-3s|%%|%[1]d" 42 "a")`, String("   42|a  |%|42")},
+		{`(parse-int "ff" 16)`, Int(255)},
+		{`(parse-int "99999999999999999999")`, bigInt("99999999999999999999")},
+		{`(split "a,b,,c" ",")`, List{String("a"), String("b"), String(""), String("c")}},
+		{`(split " a  b ")`, List{String("a"), String("b")}},
+		{`(trim "  a b ")`, String("a b")},
+		{`(trim "--a-" "-")`, String("a")},
+		{`(contains "héllo" "él")`, Bool(true)},
+		{`(starts-with "héllo" "hé")`, Bool(true)},
+		{`(ends-with "héllo" "x")`, Bool(false)},
+		{`(replace "aaa" "a" "b")`, String("bbb")},
+		{`(replace "aaa" "a" "b" 2)`, String("bba")},
+	}
+	for _, c := range tests {
+		expect(t, NewStdRuntime(), c.code, c.want)
+	}
+}
+
+// TestStringErrors : non-string arguments, bad indices and unparsable integers
+func TestStringErrors(t *testing.T) {
+	tests := []struct {
+		code string
+		kind ErrorKind
+	}{
+		{`(upper 1)`, ErrorTypeMismatch},
+		{`(join (list "a" 1) "")`, ErrorTypeMismatch},
+		{`(parse-int "12x")`, ErrorRuntime},
+		{`(parse-int "1" 1)`, ErrorRuntime},
+		{`(contains "a")`, ErrorArity},
+		{`(replace "a" "b")`, ErrorArity},
+		{`(format "%999999999d" 1)`, ErrorRuntime},
+		{`(format "%.99999f" 1.5)`, ErrorRuntime},
+	}
+	for _, c := range tests {
+		expectError(t, NewStdRuntime(), c.code, c.kind)
+	}
+}
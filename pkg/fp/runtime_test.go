@@ -0,0 +1,58 @@
+package fp
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// eval : evaluate every expression of code, value of the last one
+func eval(r *Runtime, code string) (Object, error) {
+	exprs, err := ParseAll(Tokenize(code))
+	if err != nil {
+		return nil, err
+	}
+	var v Object
+	for _, expr := range exprs {
+		if v, err = r.Step(context.Background(), expr); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// mustEval : eval, the test fails on error
+func mustEval(t *testing.T, r *Runtime, code string) Object {
+	t.Helper()
+	v, err := eval(r, code)
+	if err != nil {
+		t.Fatalf("%s: %v", code, err)
+	}
+	return v
+}
+
+// expect : value of code must be Equal to want
+func expect(t *testing.T, r *Runtime, code string, want Object) {
+	t.Helper()
+	if v := mustEval(t, r, code); !Equal(v, want) {
+		t.Errorf("%s: got %v want %v", code, v, want)
+	}
+}
+
+// expectError : evaluating code must fail with an EvalError of the given kind
+func expectError(t *testing.T, r *Runtime, code string, kind ErrorKind) {
+	t.Helper()
+	v, err := eval(r, code)
+	if err == nil {
+		t.Errorf("%s: expected %s but got %v", code, kind, v)
+		return
+	}
+	var evalErr *EvalError
+	if !errors.As(err, &evalErr) {
+		t.Errorf("%s: expected %s but got %v", code, kind, err)
+		return
+	}
+	if evalErr.Kind != kind {
+		t.Errorf("%s: expected %s but got %s: %v", code, kind, evalErr.Kind, err)
+	}
+}
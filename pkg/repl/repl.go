@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"fp/pkg/fp"
 	"sort"
+	"strings"
+	"sync"
 )
 
 type REPL interface {
@@ -17,30 +19,58 @@ type fpRepl struct {
 	runtime *fp.Runtime
 	parser  *fp.Parser
 	buffer  string
+	line    int        // number of lines read so far
+	mu      sync.Mutex // protects buffer, scripts can print from many goroutines with pmap
+}
+
+// output : writer of the runtime, script output goes into the reply
+type output struct {
+	r *fpRepl
+}
+
+func (o output) Write(p []byte) (int, error) {
+	o.r.write("%s", p)
+	return len(p), nil
 }
 
 func (r *fpRepl) ReplyInput(ctx context.Context, input string) (output string, executed bool) {
-	tokenList := fp.Tokenize(input)
+	tokenList := fp.TokenizeFile("<stdin>", input)
+	for i := range tokenList {
+		tokenList[i].Span.Start.Line += r.line
+		tokenList[i].Span.End.Line += r.line
+	}
+	r.line += strings.Count(input, "\n") + 1
 	executed = false
 	if len(tokenList) == 0 {
 		executed = true
 	} else {
 		for _, token := range tokenList {
-			expr := r.parser.Input(token)
+			expr, err := r.parser.Input(token)
+			if err != nil {
+				executed = true
+				r.writeln(err.Error())
+				break // drop the rest of the input
+			}
 			if expr != nil {
 				executed = true
 
-				lastFrame := make(fp.Frame).Update(r.runtime.Stack[len(r.runtime.Stack)-1])
+				lastEnv := *r.runtime.Stack[len(r.runtime.Stack)-1]
+				lastEnv.Frame = make(fp.Frame).Update(lastEnv.Frame)
 				stackSize := len(r.runtime.Stack)
 				output, err := r.runtime.Step(ctx, expr)
 				if err != nil {
 					if errors.Is(err, fp.InterruptError) {
 						// reset stack size
 						r.runtime.Stack = r.runtime.Stack[:stackSize-1]
-						r.runtime.Stack = append(r.runtime.Stack, lastFrame)
+						r.runtime.Stack = append(r.runtime.Stack, &lastEnv)
 						r.writeln("interrupted - stack was recovered")
 					}
-					r.writeln(err.Error())
+					var evalErr *fp.EvalError
+					if errors.As(err, &evalErr) {
+						r.writeln(evalErr.Traceback())
+					} else {
+						r.writeln(err.Error())
+					}
 					continue
 				}
 				r.write("%v\n", output)
@@ -57,27 +87,34 @@ func (r *fpRepl) ClearBuffer() (output string) {
 }
 
 func (r *fpRepl) flush() (output string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	output, r.buffer = r.buffer, ""
 	return output
 }
 
 func (r *fpRepl) write(format string, a ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	r.buffer += fmt.Sprintf(format, a...)
 }
 func (r *fpRepl) writeln(format string, a ...interface{}) {
 	r.write(format+"\n", a...)
 }
 
+// NewFP : repl evaluating with runtime, output of the runtime is captured into the replies
 func NewFP(runtime *fp.Runtime) (repl REPL, welcome string) {
 	r := &fpRepl{
 		runtime: runtime,
 		parser:  &fp.Parser{},
 		buffer:  "",
 	}
+	runtime.Stdout = output{r: r}
+	runtime.Stderr = output{r: r}
 	r.writeln("welcome to fp repl! type function or module name for help")
 	r.write("loaded modules: ")
 	var funcNameList []string
-	for k := range r.runtime.Stack[0] {
+	for k := range r.runtime.Stack[0].Frame {
 		funcNameList = append(funcNameList, string(k))
 	}
 	sort.Strings(funcNameList)
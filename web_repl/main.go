@@ -37,10 +37,22 @@ func clearBuffer(this js.Value, p []js.Value) interface{} {
 	return nil
 }
 
+// limits : snippets typed in the browser must not freeze the page
+var limits = fp.Limits{
+	MaxStackDepth:  fp.MAX_STACK_DEPTH,
+	MaxSteps:       10_000_000,
+	MaxListLength:  1_000_000,
+	MaxStringSize:  1 << 20,
+	MaxAllocations: 10_000_000,
+	MaxIntBits:     1 << 20,
+}
+
 func main() {
 	// initialize
 	var welcome string
-	r, welcome = repl.NewFP(fp.NewStdRuntime())
+	runtime := fp.NewStdRuntime()
+	runtime.Limits = limits
+	r, welcome = repl.NewFP(runtime)
 	write(welcome)
 
 	js.Global().Set("evaluate", js.FuncOf(evaluate))